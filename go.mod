@@ -1,5 +1,5 @@
 module github.com/wallrony/go-validator
 
-go 1.18
+go 1.20
 
 require golang.org/x/exp v0.0.0-20230510235704-dd950f8aeaea
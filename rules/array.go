@@ -16,8 +16,7 @@ func newArrayLenRule(len int) Rule {
 }
 
 func newArrayLenError(fieldName, argument string) FieldError {
-	message := fmt.Sprintf("the '%s' field must have %s elements", fieldName, argument)
-	return newFieldError(fieldName, message, ARRAY_LEN)
+	return newFieldError(fieldName, ARRAY_LEN, map[string]string{"length": argument})
 }
 
 func validateArrayLenFN(len int) validatorFunc {
@@ -43,8 +42,7 @@ func newArrayMaxlenRule(maxlen int) Rule {
 }
 
 func newArrayMaxlenError(fieldName, argument string) FieldError {
-	message := fmt.Sprintf("the '%s' field must have %s elements at max", fieldName, argument)
-	return newFieldError(fieldName, message, ARRAY_MAX_LEN)
+	return newFieldError(fieldName, ARRAY_MAX_LEN, map[string]string{"length": argument})
 }
 
 func validateArrayMaxlenFN(maxlen int) validatorFunc {
@@ -70,8 +68,7 @@ func newArrayMinlenRule(minlen int) Rule {
 }
 
 func newArrayMinlenError(fieldName, argument string) FieldError {
-	message := fmt.Sprintf("the '%s' field must have at least %s elements", fieldName, argument)
-	return newFieldError(fieldName, message, ARRAY_MIN_LEN)
+	return newFieldError(fieldName, ARRAY_MIN_LEN, map[string]string{"length": argument})
 }
 
 func validateArrayMinlenFN(minlen int) validatorFunc {
@@ -85,3 +82,85 @@ func validateArrayMinlenFN(minlen int) validatorFunc {
 		}
 	}
 }
+
+// ArrayUnique
+type arrayUniqueRule struct {
+	*rule
+}
+
+// newArrayUniqueRule rejects repeated elements or, with an argument, elements
+// that repeat the value of that key ("slice:unique=sku" for line items).
+func newArrayUniqueRule(key string) Rule {
+	description := "verify if a value is an array without duplicate elements"
+	if key != "" {
+		description = fmt.Sprintf("verify if a value is an array without duplicate '%s' in its elements", key)
+	}
+	return &arrayUniqueRule{&rule{
+		typeName:    ARRAY_UNIQUE,
+		description: description,
+		validator: func(value interface{}) bool {
+			return len(findDuplicates(value, key)) == 0
+		},
+		argument: key,
+	}}
+}
+
+// GenerateErrors reports each duplicate element, naming the element it
+// repeats ("'items[3]' field duplicates 'items[1]'").
+func (r *arrayUniqueRule) GenerateErrors(fieldName string, value interface{}) []FieldError {
+	var errs []FieldError
+	for _, duplicate := range findDuplicates(value, r.argument) {
+		params := map[string]string{"other": fmt.Sprintf("%s[%d]", fieldName, duplicate.of), "key": r.argument}
+		messageKey := arrayDuplicateMessage
+		if r.argument != "" {
+			messageKey = arrayDuplicateKeyMessage
+		}
+		err := newKeyedFieldError(fmt.Sprintf("%s[%d]", fieldName, duplicate.index), ARRAY_UNIQUE, messageKey, params)
+		err.argument = r.argument
+		err.value = duplicate.value
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func newArrayUniqueError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, ARRAY_UNIQUE, map[string]string{"key": argument})
+}
+
+type duplicate struct {
+	index, of int
+	value     interface{}
+}
+
+// findDuplicates finds the elements of a slice equal to an earlier one,
+// comparing the value of the key in each element when a key is informed.
+// Elements without the key are ignored.
+func findDuplicates(value interface{}, key string) []duplicate {
+	if value == nil || reflect.TypeOf(value).Kind() != reflect.Slice {
+		return nil
+	}
+	elements := reflect.ValueOf(value)
+	var duplicates []duplicate
+	var seen []int
+	for i := 0; i < elements.Len(); i++ {
+		element := elements.Index(i).Interface()
+		if key != "" {
+			object, _ := element.(map[string]interface{})
+			if element = object[key]; element == nil {
+				continue
+			}
+		}
+		for _, j := range seen {
+			other := elements.Index(j).Interface()
+			if key != "" {
+				other = other.(map[string]interface{})[key]
+			}
+			if reflect.DeepEqual(element, other) {
+				duplicates = append(duplicates, duplicate{i, j, element})
+				break
+			}
+		}
+		seen = append(seen, i)
+	}
+	return duplicates
+}
@@ -0,0 +1,65 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArrayUniqueRule(t *testing.T) {
+	runRuleTests(t, []ruleTest{
+		{"slice:unique", []interface{}{"a", "b"}, true},
+		{"slice:unique", []interface{}{"a", "b", "a"}, false},
+		{"slice:unique", []interface{}{float64(1), "1"}, true},
+		{"slice:unique", []interface{}{[]interface{}{"a"}, []interface{}{"a"}}, false},
+		{"slice:unique", []interface{}{}, true},
+		{"slice:unique", nil, true},
+		{"slice:unique=sku", []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "b"}}, true},
+		{"slice:unique=sku", []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "a", "qty": 2}}, false},
+		{"slice:unique=sku", []interface{}{map[string]interface{}{}, map[string]interface{}{}}, true},
+	})
+}
+
+func TestArrayUniqueRuleErrors(t *testing.T) {
+	tests := []struct {
+		hint     string
+		value    []interface{}
+		names    []string
+		messages []string
+	}{
+		{
+			"slice:unique",
+			[]interface{}{"a", "b", "a", "b", "a"},
+			[]string{"tags[2]", "tags[3]", "tags[4]"},
+			[]string{"the 'tags[2]' field duplicates 'tags[0]'", "the 'tags[3]' field duplicates 'tags[1]'", "the 'tags[4]' field duplicates 'tags[0]'"},
+		},
+		{
+			"slice:unique=sku",
+			[]interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "a"}},
+			[]string{"tags[1]"},
+			[]string{"the 'tags[1]' field has the same 'sku' as 'tags[0]'"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.hint, func(t *testing.T) {
+			rule := GetRuleByHint(test.hint).(MultiErrorRule)
+			var names, messages []string
+			for _, err := range rule.GenerateErrors("tags", test.value) {
+				names = append(names, err.Name())
+				messages = append(messages, err.Message())
+			}
+			if !reflect.DeepEqual(names, test.names) || !reflect.DeepEqual(messages, test.messages) {
+				t.Errorf("got %q %q, want %q %q", names, messages, test.names, test.messages)
+			}
+		})
+	}
+}
+
+func TestArrayLengthRules(t *testing.T) {
+	runRuleTests(t, []ruleTest{
+		{"slice:len=2", []interface{}{1, 2}, true},
+		{"slice:len=2", []interface{}{1}, false},
+		{"slice:minlen=1", []interface{}{1}, true},
+		{"slice:minlen=1", []interface{}{}, false},
+		{"slice:maxlen=1", []interface{}{1, 2}, false},
+	})
+}
@@ -3,15 +3,43 @@ package rules
 import "regexp"
 
 // Rule Compilers
+var requiredIfRuleCompiler = regexp.MustCompile(`^required_if=([^:]+:.+)$`)
+var requiredUnlessRuleCompiler = regexp.MustCompile(`^required_unless=([^:]+:.+)$`)
+var requiredWithRuleCompiler = regexp.MustCompile(`^required_with=(.+)$`)
+var requiredWithoutRuleCompiler = regexp.MustCompile(`^required_without=(.+)$`)
 var lengthRuleCompiler = regexp.MustCompile(`^len=(\d+)$`)
 var minLengthRuleCompiler = regexp.MustCompile(`^minlen=(\d+)$`)
 var maxLengthRuleCompiler = regexp.MustCompile(`^maxlen=(\d+)$`)
 var arrayLengthRuleCompiler = regexp.MustCompile(`^slice:len=(\d+)$`)
 var arrayMinLengthRuleCompiler = regexp.MustCompile(`^slice:minlen=(\d+)$`)
 var arrayMaxLengthRuleCompiler = regexp.MustCompile(`^slice:maxlen=(\d+)$`)
+var arrayUniqueRuleCompiler = regexp.MustCompile(`^slice:unique(?:=(.+))?$`)
+
+var minRuleCompiler = regexp.MustCompile(`^min=(-?\d+(?:\.\d+)?)$`)
+var maxRuleCompiler = regexp.MustCompile(`^max=(-?\d+(?:\.\d+)?)$`)
+var greaterThanRuleCompiler = regexp.MustCompile(`^gt=(-?\d+(?:\.\d+)?)$`)
+var greaterThanOrEqualRuleCompiler = regexp.MustCompile(`^gte=(-?\d+(?:\.\d+)?)$`)
+var lessThanRuleCompiler = regexp.MustCompile(`^lt=(-?\d+(?:\.\d+)?)$`)
+var lessThanOrEqualRuleCompiler = regexp.MustCompile(`^lte=(-?\d+(?:\.\d+)?)$`)
+var betweenRuleCompiler = regexp.MustCompile(`^between=(-?\d+(?:\.\d+)?:-?\d+(?:\.\d+)?)$`)
+
+var equalFieldRuleCompiler = regexp.MustCompile(`^eqfield=(.+)$`)
+var notEqualFieldRuleCompiler = regexp.MustCompile(`^nefield=(.+)$`)
+var greaterThanFieldRuleCompiler = regexp.MustCompile(`^gtfield=(.+)$`)
+var lessThanFieldRuleCompiler = regexp.MustCompile(`^ltfield=(.+)$`)
+
+var beforeRuleCompiler = regexp.MustCompile(`^before=(.+)$`)
+var afterRuleCompiler = regexp.MustCompile(`^after=(.+)$`)
+var pastRuleCompiler = regexp.MustCompile(`^past$`)
+var futureRuleCompiler = regexp.MustCompile(`^future$`)
+var minAgeRuleCompiler = regexp.MustCompile(`^minage=(\d+)$`)
+var maxAgeRuleCompiler = regexp.MustCompile(`^maxage=(\d+)$`)
 
 var emailRuleCompiler = regexp.MustCompile(`^email$`)
 var dateRuleCompiler = regexp.MustCompile(`^date=?([0-9-\/]{0,10}?)?$`)
+var dateTimeRuleCompiler = regexp.MustCompile(`^datetime(?:=(.+))?$`)
+var patternRuleCompiler = regexp.MustCompile(`^pattern=(.+)$`)
+var oneOfRuleCompiler = regexp.MustCompile(`^(not)?oneof(ci)?=(.+)$`)
 
 var lengthCompilerRuleBuilder = map[*regexp.Regexp]func(argument int) Rule{
 	lengthRuleCompiler:         newLengthRule,
@@ -21,3 +49,36 @@ var lengthCompilerRuleBuilder = map[*regexp.Regexp]func(argument int) Rule{
 	arrayMinLengthRuleCompiler: newArrayMinlenRule,
 	arrayMaxLengthRuleCompiler: newArrayMaxlenRule,
 }
+
+var numberCompilerRuleBuilder = map[*regexp.Regexp]func(argument string) Rule{
+	minRuleCompiler:                newMinRule,
+	maxRuleCompiler:                newMaxRule,
+	greaterThanRuleCompiler:        newGreaterThanRule,
+	greaterThanOrEqualRuleCompiler: newGreaterThanOrEqualRule,
+	lessThanRuleCompiler:           newLessThanRule,
+	lessThanOrEqualRuleCompiler:    newLessThanOrEqualRule,
+	betweenRuleCompiler:            newBetweenRule,
+}
+
+var fieldCompilerRuleBuilder = map[*regexp.Regexp]func(otherField string) Rule{
+	equalFieldRuleCompiler:       newEqualFieldRule,
+	notEqualFieldRuleCompiler:    newNotEqualFieldRule,
+	greaterThanFieldRuleCompiler: newGreaterThanFieldRule,
+	lessThanFieldRuleCompiler:    newLessThanFieldRule,
+}
+
+var requiredCompilerRuleBuilder = map[*regexp.Regexp]func(argument string) Rule{
+	requiredIfRuleCompiler:      newRequiredIfRule,
+	requiredUnlessRuleCompiler:  newRequiredUnlessRule,
+	requiredWithRuleCompiler:    newRequiredWithRule,
+	requiredWithoutRuleCompiler: newRequiredWithoutRule,
+}
+
+var timeCompilerRuleBuilder = map[*regexp.Regexp]func(argument string) Rule{
+	beforeRuleCompiler: newBeforeRule,
+	afterRuleCompiler:  newAfterRule,
+	pastRuleCompiler:   newPastRule,
+	futureRuleCompiler: newFutureRule,
+	minAgeRuleCompiler: newMinAgeRule,
+	maxAgeRuleCompiler: newMaxAgeRule,
+}
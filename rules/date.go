@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -16,7 +15,9 @@ func validateDateRule(format string) Rule {
 
 func validateDateFN(format string) validatorFunc {
 	return func(value interface{}) bool {
-		if date, ok := value.(string); !ok {
+		if _, ok := value.(time.Time); ok {
+			return true // already parsed, e.g. by the validator's coercion
+		} else if date, ok := value.(string); !ok {
 			return false
 		} else if _, err := time.Parse(format, date); err != nil {
 			return false
@@ -26,6 +27,5 @@ func validateDateFN(format string) validatorFunc {
 }
 
 func newDateValidationError(fieldName, format string) FieldError {
-	message := fmt.Sprintf("'%s' field doesn't match with the '%s' format", fieldName, format)
-	return newFieldError(fieldName, message, DATE_VALIDATION)
+	return newFieldError(fieldName, DATE_VALIDATION, map[string]string{"format": format})
 }
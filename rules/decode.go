@@ -0,0 +1,7 @@
+package rules
+
+// NewDecodeError reports data that couldn't be decoded at all, such as a
+// malformed JSON body. An empty field name refers to the whole document.
+func NewDecodeError(fieldName, detail string) FieldError {
+	return newFieldError(fieldName, DECODE, map[string]string{"detail": detail})
+}
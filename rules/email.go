@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"net/mail"
 )
 
@@ -23,6 +22,5 @@ func validateEmailFN(value interface{}) bool {
 }
 
 func newEmailValidationError(fieldName string) FieldError {
-	message := fmt.Sprintf("the value provided for the '%s' field isn't a valid email", fieldName)
-	return newFieldError(fieldName, message, EMAIL_VALIDATION)
+	return newFieldError(fieldName, EMAIL_VALIDATION, nil)
 }
@@ -1,19 +1,51 @@
 package rules
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type FieldError interface {
+	error
 	Name() string
 	Message() string
+	MessageIn(locale string) string
 	RuleType() string
+	Argument() string
+	Value() interface{}
+	Path() string
+	PathSegments() []string
 }
 
+// fieldError keeps the message key and its parameters instead of the final
+// text, so the message can be rendered in any locale. The keys are tried in
+// order and fallback is used when no catalog has any of them.
 type fieldError struct {
 	name     string
-	message  string
 	ruleType string
+	argument string
+	value    interface{}
+	keys     []string
+	params   map[string]string
+	fallback string
+}
+
+func newFieldError(name, ruleType string, params map[string]string) FieldError {
+	return newKeyedFieldError(name, ruleType, ruleType, params)
+}
+
+func newKeyedFieldError(name, ruleType, key string, params map[string]string) *fieldError {
+	if params == nil {
+		params = map[string]string{}
+	}
+	params["field"] = name
+	return &fieldError{name: name, ruleType: ruleType, keys: []string{key}, params: params}
 }
 
-func newFieldError(name, message, ruleType string) FieldError {
-	return &fieldError{name, message, ruleType}
+func (f *fieldError) withFallback(message string) *fieldError {
+	f.keys = nil
+	f.fallback = message
+	return f
 }
 
 func (f *fieldError) Name() string {
@@ -21,13 +53,92 @@ func (f *fieldError) Name() string {
 }
 
 func (f *fieldError) Message() string {
-	return f.message
+	return f.MessageIn(DefaultLocale)
+}
+
+func (f *fieldError) MessageIn(locale string) string {
+	for _, key := range f.keys {
+		if template, ok := findMessage(locale, key); ok {
+			return renderMessage(template, f.params)
+		}
+	}
+	return f.fallback
 }
 
 func (f *fieldError) RuleType() string {
 	return f.ruleType
 }
 
+func (f *fieldError) Argument() string {
+	return f.argument
+}
+
+// Value is the rejected value, as it was received.
+func (f *fieldError) Value() interface{} {
+	return f.value
+}
+
+// Path is the RFC 6901 JSON pointer of the field, e.g. "/profiles/2/email".
+func (f *fieldError) Path() string {
+	return jsonPointer(f.PathSegments())
+}
+
+// PathSegments is the path of the field split by level, e.g.
+// ["profiles", "2", "email"].
+func (f *fieldError) PathSegments() []string {
+	return splitPath(f.name)
+}
+
+func (f *fieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Field    string      `json:"field"`
+		Path     string      `json:"path"`
+		Rule     string      `json:"rule"`
+		Argument string      `json:"argument,omitempty"`
+		Value    interface{} `json:"value,omitempty"`
+		Message  string      `json:"message"`
+	}{f.name, f.Path(), f.ruleType, f.argument, f.value, f.Message()})
+}
+
+func (f *fieldError) Error() string {
+	return f.Message()
+}
+
+// WithMessage replaces the message of a field error, in every locale, by a
+// template that may use the {field}, {argument} and {value} placeholders.
+func WithMessage(err FieldError, template, argument string, value interface{}) FieldError {
+	params := map[string]string{"field": err.Name(), "argument": argument, "value": ""}
+	if value != nil {
+		params["value"] = fmt.Sprint(value)
+	}
+	message := renderMessage(template, params)
+	if f, ok := err.(*fieldError); ok {
+		overridden := *f
+		return overridden.withFallback(message)
+	}
+	overridden := newKeyedFieldError(err.Name(), err.RuleType(), "", nil).withFallback(message)
+	overridden.argument = err.Argument()
+	overridden.value = err.Value()
+	return overridden
+}
+
+// WithValue records the rejected value in a field error.
+func WithValue(err FieldError, value interface{}) FieldError {
+	if f, ok := err.(*fieldError); ok {
+		withValue := *f
+		withValue.value = value
+		return &withValue
+	}
+	return err
+}
+
+func withArgument(err FieldError, argument string) FieldError {
+	if f, ok := err.(*fieldError); ok {
+		f.argument = argument
+	}
+	return err
+}
+
 func newErrorByType(t, fieldName, argument string) FieldError {
 	switch t {
 	case REQUIRED:
@@ -50,10 +161,56 @@ func newErrorByType(t, fieldName, argument string) FieldError {
 		return newArrayMaxlenError(fieldName, argument)
 	case ARRAY_MIN_LEN:
 		return newArrayMinlenError(fieldName, argument)
+	case ARRAY_UNIQUE:
+		return newArrayUniqueError(fieldName, argument)
+	case MIN:
+		return newMinError(fieldName, argument)
+	case MAX:
+		return newMaxError(fieldName, argument)
+	case GREATER_THAN:
+		return newGreaterThanError(fieldName, argument)
+	case GREATER_THAN_OR_EQUAL:
+		return newGreaterThanOrEqualError(fieldName, argument)
+	case LESS_THAN:
+		return newLessThanError(fieldName, argument)
+	case LESS_THAN_OR_EQUAL:
+		return newLessThanOrEqualError(fieldName, argument)
+	case BETWEEN:
+		return newBetweenError(fieldName, argument)
+	case PATTERN:
+		return newPatternError(fieldName, argument)
+	case ONE_OF:
+		return newOneOfError(fieldName, argument)
+	case NOT_ONE_OF:
+		return newNotOneOfError(fieldName, argument)
+	case EQUAL_FIELD:
+		return newEqualFieldError(fieldName, argument)
+	case NOT_EQUAL_FIELD:
+		return newNotEqualFieldError(fieldName, argument)
+	case GREATER_THAN_FIELD:
+		return newGreaterThanFieldError(fieldName, argument)
+	case LESS_THAN_FIELD:
+		return newLessThanFieldError(fieldName, argument)
+	case BEFORE:
+		return newBeforeError(fieldName, argument)
+	case AFTER:
+		return newAfterError(fieldName, argument)
+	case PAST:
+		return newPastError(fieldName)
+	case FUTURE:
+		return newFutureError(fieldName)
+	case MIN_AGE:
+		return newMinAgeError(fieldName, argument)
+	case MAX_AGE:
+		return newMaxAgeError(fieldName, argument)
+	case NOT_NULL:
+		return newNotNullError(fieldName)
+	case UNKNOWN:
+		return newUnknownFieldError(fieldName)
 	}
-	return nil
+	return newCustomRuleError(t, fieldName, argument)
 }
 
 func NewErrorByField(ruleType, fieldName, argument string) FieldError {
-	return newErrorByType(ruleType, fieldName, argument)
+	return withArgument(newErrorByType(ruleType, fieldName, argument), argument)
 }
@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFieldError(t *testing.T) {
+	err := WithValue(NewErrorByField(MIN, "profiles[2].age", "18"), float64(17))
+	if err.Name() != "profiles[2].age" || err.RuleType() != MIN || err.Argument() != "18" || err.Value() != float64(17) {
+		t.Errorf("got %s %s %s %v", err.Name(), err.RuleType(), err.Argument(), err.Value())
+	}
+	if got, want := err.Path(), "/profiles/2/age"; got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+	if got, want := err.Error(), "'profiles[2].age' field must be at least 18"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestFieldErrorMarshalJSON(t *testing.T) {
+	tests := []struct {
+		err  FieldError
+		want string
+	}{
+		{
+			WithValue(NewErrorByField(MAX_LENGTH, "name", "10"), "My Awesome Name"),
+			`{"field":"name","path":"/name","rule":"maxlength","argument":"10","value":"My Awesome Name","message":"'name' field must have 10 characters at max"}`,
+		},
+		{
+			NewErrorByField(EMAIL_VALIDATION, "email", ""),
+			`{"field":"email","path":"/email","rule":"email","message":"the value provided for the 'email' field isn't a valid email"}`,
+		},
+		{
+			NewDecodeError("", "unexpected EOF"),
+			`{"field":"","path":"","rule":"decode","message":"the data isn't a valid JSON object: unexpected EOF"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.err.RuleType(), func(t *testing.T) {
+			got, err := json.Marshal(test.err)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWithMessage(t *testing.T) {
+	err := WithMessage(NewErrorByField(MIN, "age", "18"), "{field} must be {argument} or more, not {value}", "18", float64(17))
+	want := "age must be 18 or more, not 17"
+	if err.Message() != want || err.MessageIn("pt-BR") != want {
+		t.Errorf("got %q and %q, want %q in every locale", err.Message(), err.MessageIn("pt-BR"), want)
+	}
+	if err.RuleType() != MIN || err.Argument() != "18" {
+		t.Errorf("got %s with %q, want the rule and argument kept", err.RuleType(), err.Argument())
+	}
+}
+
+func TestFieldErrorWithErrorsAs(t *testing.T) {
+	wrapped := fmt.Errorf("saving: %w", NewErrorByField(REQUIRED, "name", "string"))
+	var fieldError FieldError
+	if !errors.As(wrapped, &fieldError) || fieldError.Name() != "name" {
+		t.Errorf("errors.As didn't find the field error in %v", wrapped)
+	}
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func newEqualFieldRule(otherField string) Rule {
+	return newFieldComparisonRule(EQUAL_FIELD, "verify if a value is equal to the '%s' field", otherField, func(result int, comparable, equal bool) bool {
+		return equal
+	}, false)
+}
+
+func newNotEqualFieldRule(otherField string) Rule {
+	return newFieldComparisonRule(NOT_EQUAL_FIELD, "verify if a value is different from the '%s' field", otherField, func(result int, comparable, equal bool) bool {
+		return !equal
+	}, true)
+}
+
+func newGreaterThanFieldRule(otherField string) Rule {
+	return newFieldComparisonRule(GREATER_THAN_FIELD, "verify if a value is greater than the '%s' field", otherField, func(result int, comparable, equal bool) bool {
+		return comparable && result > 0
+	}, true)
+}
+
+func newLessThanFieldRule(otherField string) Rule {
+	return newFieldComparisonRule(LESS_THAN_FIELD, "verify if a value is less than the '%s' field", otherField, func(result int, comparable, equal bool) bool {
+		return comparable && result < 0
+	}, true)
+}
+
+// newFieldComparisonRule builds a rule comparing a value with another field
+// of the data. validWhenMissing is the result when the other field is absent.
+func newFieldComparisonRule(typeName, description, otherField string, accept func(result int, comparable, equal bool) bool, validWhenMissing bool) Rule {
+	return &rule{
+		typeName:         typeName,
+		description:      fmt.Sprintf(description, otherField),
+		contextValidator: fieldComparisonValidatorFN(otherField, accept, validWhenMissing),
+		argument:         otherField,
+	}
+}
+
+func fieldComparisonValidatorFN(otherField string, accept func(result int, comparable, equal bool) bool, validWhenMissing bool) contextValidatorFunc {
+	return func(value interface{}, ctx Context) bool {
+		if ctx == nil {
+			return validWhenMissing
+		}
+		other, ok := ctx.Lookup(otherField)
+		if !ok {
+			return validWhenMissing
+		}
+		result, comparable := compareValues(value, other, ctx.DateFormat())
+		equal := (comparable && result == 0) || (!comparable && reflect.DeepEqual(value, other))
+		return accept(result, comparable, equal)
+	}
+}
+
+// compareValues orders two numbers, two dates (time.Time values or strings in
+// the field's date format) or two strings, returning false when they can't be
+// ordered.
+func compareValues(a, b interface{}, dateFormat string) (int, bool) {
+	_, isTimeA := a.(time.Time)
+	_, isTimeB := b.(time.Time)
+	if isTimeA || isTimeB {
+		dateA, okA := timeOf(a, dateFormat)
+		dateB, okB := timeOf(b, dateFormat)
+		return dateA.Compare(dateB), okA && okB
+	}
+	if textA, ok := a.(string); ok {
+		textB, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		if dateFormat != "" {
+			dateA, errA := time.Parse(dateFormat, textA)
+			dateB, errB := time.Parse(dateFormat, textB)
+			if errA == nil && errB == nil {
+				return dateA.Compare(dateB), true
+			}
+		}
+		return strings.Compare(textA, textB), true
+	}
+	if !isNumber(a) || !isNumber(b) {
+		return 0, false
+	}
+	return compareNumber(a, fmt.Sprint(b))
+}
+
+func newEqualFieldError(fieldName, otherField string) FieldError {
+	return newFieldError(fieldName, EQUAL_FIELD, map[string]string{"other": otherField})
+}
+
+func newNotEqualFieldError(fieldName, otherField string) FieldError {
+	return newFieldError(fieldName, NOT_EQUAL_FIELD, map[string]string{"other": otherField})
+}
+
+func newGreaterThanFieldError(fieldName, otherField string) FieldError {
+	return newFieldError(fieldName, GREATER_THAN_FIELD, map[string]string{"other": otherField})
+}
+
+func newLessThanFieldError(fieldName, otherField string) FieldError {
+	return newFieldError(fieldName, LESS_THAN_FIELD, map[string]string{"other": otherField})
+}
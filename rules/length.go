@@ -32,18 +32,15 @@ func newLengthRule(length int) Rule {
 }
 
 func newLengthError(fieldName string, length string) FieldError {
-	message := fmt.Sprintf("'%s' field must have %s characters", fieldName, length)
-	return newFieldError(fieldName, message, LENGTH)
+	return newFieldError(fieldName, LENGTH, map[string]string{"length": length})
 }
 
 func newMinLengthError(fieldName string, length string) FieldError {
-	message := fmt.Sprintf("'%s' field must have at least %s characters", fieldName, length)
-	return newFieldError(fieldName, message, MIN_LENGTH)
+	return newFieldError(fieldName, MIN_LENGTH, map[string]string{"length": length})
 }
 
 func newMaxLengthError(fieldName string, length string) FieldError {
-	message := fmt.Sprintf("'%s' field must have %s characters at max", fieldName, length)
-	return newFieldError(fieldName, message, MAX_LENGTH)
+	return newFieldError(fieldName, MAX_LENGTH, map[string]string{"length": length})
 }
 
 func minLengthValidatorFN(length int) validatorFunc {
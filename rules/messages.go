@@ -0,0 +1,225 @@
+package rules
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of FieldError.Message and the fallback of every
+// other locale.
+const DefaultLocale = "en"
+
+// Message keys that aren't rule types.
+const (
+	requiredIfMessage        = "required_if"
+	requiredIfAnyMessage     = "required_if_any"
+	requiredUnlessMessage    = "required_unless"
+	requiredUnlessAnyMessage = "required_unless_any"
+	requiredWithMessage      = "required_with"
+	requiredWithoutMessage   = "required_without"
+	customMessage            = "custom"
+	arrayDuplicateMessage    = "slice:unique_duplicate"
+	arrayDuplicateKeyMessage = "slice:unique_duplicate_key"
+)
+
+var (
+	catalogsMutex sync.RWMutex
+	catalogs      = map[string]map[string]string{
+		"en": {
+			REQUIRED:                 "'{field}' field of type '{type}' is missing or empty",
+			requiredIfMessage:        "'{field}' field is required when '{other}' is '{value}'",
+			requiredIfAnyMessage:     "'{field}' field is required when '{other}' is one of: {values}",
+			requiredUnlessMessage:    "'{field}' field is required unless '{other}' is '{value}'",
+			requiredUnlessAnyMessage: "'{field}' field is required unless '{other}' is one of: {values}",
+			requiredWithMessage:      "'{field}' field is required when '{other}' is present",
+			requiredWithoutMessage:   "'{field}' field is required when '{other}' is missing",
+			TYPE:                     "'{field}' field type must be '{type}'",
+			LENGTH:                   "'{field}' field must have {length} characters",
+			MIN_LENGTH:               "'{field}' field must have at least {length} characters",
+			MAX_LENGTH:               "'{field}' field must have {length} characters at max",
+			EMAIL_VALIDATION:         "the value provided for the '{field}' field isn't a valid email",
+			DATE_VALIDATION:          "'{field}' field doesn't match with the '{format}' format",
+			ARRAY_LEN:                "the '{field}' field must have {length} elements",
+			ARRAY_MIN_LEN:            "the '{field}' field must have at least {length} elements",
+			ARRAY_MAX_LEN:            "the '{field}' field must have {length} elements at max",
+			ARRAY_UNIQUE:             "the '{field}' field must not have duplicate elements",
+			arrayDuplicateMessage:    "the '{field}' field duplicates '{other}'",
+			arrayDuplicateKeyMessage: "the '{field}' field has the same '{key}' as '{other}'",
+			MIN:                      "'{field}' field must be at least {min}",
+			MAX:                      "'{field}' field must be {max} at max",
+			GREATER_THAN:             "'{field}' field must be greater than {limit}",
+			GREATER_THAN_OR_EQUAL:    "'{field}' field must be greater than or equal to {limit}",
+			LESS_THAN:                "'{field}' field must be less than {limit}",
+			LESS_THAN_OR_EQUAL:       "'{field}' field must be less than or equal to {limit}",
+			BETWEEN:                  "'{field}' field must be between {min} and {max}",
+			PATTERN:                  "the value provided for the '{field}' field doesn't match the '{pattern}' pattern",
+			ONE_OF:                   "the value provided for the '{field}' field must be one of: {values}",
+			NOT_ONE_OF:               "the value provided for the '{field}' field must not be one of: {values}",
+			EQUAL_FIELD:              "'{field}' field must be equal to the '{other}' field",
+			NOT_EQUAL_FIELD:          "'{field}' field must be different from the '{other}' field",
+			GREATER_THAN_FIELD:       "'{field}' field must be greater than the '{other}' field",
+			LESS_THAN_FIELD:          "'{field}' field must be less than the '{other}' field",
+			BEFORE:                   "'{field}' field must be a date before {date}",
+			AFTER:                    "'{field}' field must be a date after {date}",
+			PAST:                     "'{field}' field must be a date in the past",
+			FUTURE:                   "'{field}' field must be a date in the future",
+			MIN_AGE:                  "'{field}' field must be a date at least {age} years ago",
+			MAX_AGE:                  "'{field}' field must be a date at most {age} years ago",
+			customMessage:            "the value provided for the '{field}' field doesn't satisfy the '{rule}' rule",
+			DECODE:                   "the data isn't a valid JSON object: {detail}",
+			UNKNOWN:                  "'{field}' field isn't expected",
+			NOT_NULL:                 "'{field}' field can't be null",
+		},
+		"pt-BR": {
+			REQUIRED:                 "o campo '{field}' do tipo '{type}' não foi informado ou está vazio",
+			requiredIfMessage:        "o campo '{field}' é obrigatório quando '{other}' é '{value}'",
+			requiredIfAnyMessage:     "o campo '{field}' é obrigatório quando '{other}' é um dos valores: {values}",
+			requiredUnlessMessage:    "o campo '{field}' é obrigatório a menos que '{other}' seja '{value}'",
+			requiredUnlessAnyMessage: "o campo '{field}' é obrigatório a menos que '{other}' seja um dos valores: {values}",
+			requiredWithMessage:      "o campo '{field}' é obrigatório quando '{other}' é informado",
+			requiredWithoutMessage:   "o campo '{field}' é obrigatório quando '{other}' não é informado",
+			TYPE:                     "o tipo do campo '{field}' deve ser '{type}'",
+			LENGTH:                   "o campo '{field}' deve ter {length} caracteres",
+			MIN_LENGTH:               "o campo '{field}' deve ter pelo menos {length} caracteres",
+			MAX_LENGTH:               "o campo '{field}' deve ter no máximo {length} caracteres",
+			EMAIL_VALIDATION:         "o valor informado no campo '{field}' não é um email válido",
+			DATE_VALIDATION:          "o campo '{field}' não está no formato '{format}'",
+			ARRAY_LEN:                "o campo '{field}' deve ter {length} elementos",
+			ARRAY_MIN_LEN:            "o campo '{field}' deve ter pelo menos {length} elementos",
+			ARRAY_MAX_LEN:            "o campo '{field}' deve ter no máximo {length} elementos",
+			ARRAY_UNIQUE:             "o campo '{field}' não pode ter elementos repetidos",
+			arrayDuplicateMessage:    "o campo '{field}' repete '{other}'",
+			arrayDuplicateKeyMessage: "o campo '{field}' tem o mesmo '{key}' que '{other}'",
+			MIN:                      "o campo '{field}' deve ser no mínimo {min}",
+			MAX:                      "o campo '{field}' deve ser no máximo {max}",
+			GREATER_THAN:             "o campo '{field}' deve ser maior que {limit}",
+			GREATER_THAN_OR_EQUAL:    "o campo '{field}' deve ser maior ou igual a {limit}",
+			LESS_THAN:                "o campo '{field}' deve ser menor que {limit}",
+			LESS_THAN_OR_EQUAL:       "o campo '{field}' deve ser menor ou igual a {limit}",
+			BETWEEN:                  "o campo '{field}' deve estar entre {min} e {max}",
+			PATTERN:                  "o valor informado no campo '{field}' não corresponde ao padrão '{pattern}'",
+			ONE_OF:                   "o valor informado no campo '{field}' deve ser um dos valores: {values}",
+			NOT_ONE_OF:               "o valor informado no campo '{field}' não pode ser um dos valores: {values}",
+			EQUAL_FIELD:              "o campo '{field}' deve ser igual ao campo '{other}'",
+			NOT_EQUAL_FIELD:          "o campo '{field}' deve ser diferente do campo '{other}'",
+			GREATER_THAN_FIELD:       "o campo '{field}' deve ser maior que o campo '{other}'",
+			LESS_THAN_FIELD:          "o campo '{field}' deve ser menor que o campo '{other}'",
+			BEFORE:                   "o campo '{field}' deve ser uma data anterior a {date}",
+			AFTER:                    "o campo '{field}' deve ser uma data posterior a {date}",
+			PAST:                     "o campo '{field}' deve ser uma data no passado",
+			FUTURE:                   "o campo '{field}' deve ser uma data no futuro",
+			MIN_AGE:                  "o campo '{field}' deve ser uma data de pelo menos {age} anos atrás",
+			MAX_AGE:                  "o campo '{field}' deve ser uma data de no máximo {age} anos atrás",
+			customMessage:            "o valor informado no campo '{field}' não atende à regra '{rule}'",
+			DECODE:                   "os dados não são um objeto JSON válido: {detail}",
+			UNKNOWN:                  "o campo '{field}' não é esperado",
+			NOT_NULL:                 "o campo '{field}' não pode ser nulo",
+		},
+	}
+)
+
+// RegisterMessages adds or replaces message templates of a locale, creating
+// the locale when it doesn't exist. Keys are rule types (or the names of
+// custom rules) and templates use placeholders such as {field}.
+func RegisterMessages(locale string, messages map[string]string) {
+	catalogsMutex.Lock()
+	defer catalogsMutex.Unlock()
+	for available, catalog := range catalogs {
+		if normalizeLocale(available) == normalizeLocale(locale) {
+			for key, template := range messages {
+				catalog[key] = template
+			}
+			return
+		}
+	}
+	catalog := make(map[string]string, len(messages))
+	for key, template := range messages {
+		catalog[key] = template
+	}
+	catalogs[locale] = catalog
+}
+
+// MatchLocale picks the best available locale for an Accept-Language header
+// value (e.g. "pt-BR,pt;q=0.9,en;q=0.8"), falling back to DefaultLocale.
+func MatchLocale(acceptLanguage string) string {
+	type weightedLocale struct {
+		locale string
+		weight float64
+	}
+	var locales []weightedLocale
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		if locale != "" && weight > 0 {
+			locales = append(locales, weightedLocale{locale, weight})
+		}
+	}
+	sort.SliceStable(locales, func(i, j int) bool {
+		return locales[i].weight > locales[j].weight
+	})
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	for _, candidate := range locales {
+		if resolved, ok := resolveLocale(candidate.locale); ok {
+			return resolved
+		}
+	}
+	return DefaultLocale
+}
+
+// resolveLocale finds the catalog of a locale ignoring case and "_" versus
+// "-", falling back to a catalog of the same language ("pt" finds "pt-BR").
+// The caller must hold catalogsMutex.
+func resolveLocale(locale string) (string, bool) {
+	normalized := normalizeLocale(locale)
+	if normalized == "" {
+		return "", false
+	}
+	language, _, _ := strings.Cut(normalized, "-")
+	var sameLanguage []string
+	for available := range catalogs {
+		if normalizeLocale(available) == normalized {
+			return available, true
+		}
+		if availableLanguage, _, _ := strings.Cut(normalizeLocale(available), "-"); availableLanguage == language {
+			sameLanguage = append(sameLanguage, available)
+		}
+	}
+	if len(sameLanguage) == 0 {
+		return "", false
+	}
+	sort.Strings(sameLanguage)
+	return sameLanguage[0], true
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func findMessage(locale, key string) (string, bool) {
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	for _, candidate := range []string{locale, DefaultLocale} {
+		if resolved, ok := resolveLocale(candidate); ok {
+			if template, ok := catalogs[resolved][key]; ok {
+				return template, true
+			}
+		}
+	}
+	return "", false
+}
+
+func renderMessage(template string, params map[string]string) string {
+	replacements := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
@@ -0,0 +1,66 @@
+package rules
+
+import "testing"
+
+func TestMessageIn(t *testing.T) {
+	err := NewErrorByField(MAX_LENGTH, "name", "10")
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", "'name' field must have 10 characters at max"},
+		{"pt-BR", "o campo 'name' deve ter no máximo 10 caracteres"},
+		{"pt_br", "o campo 'name' deve ter no máximo 10 caracteres"},
+		{"pt", "o campo 'name' deve ter no máximo 10 caracteres"},
+		{"fr", "'name' field must have 10 characters at max"},
+		{"", "'name' field must have 10 characters at max"},
+	}
+	for _, test := range tests {
+		t.Run(test.locale, func(t *testing.T) {
+			if got := err.MessageIn(test.locale); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+	if got := err.Message(); got != err.MessageIn(DefaultLocale) {
+		t.Errorf("Message() = %q, want the %s message", got, DefaultLocale)
+	}
+}
+
+func TestMatchLocale(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", DefaultLocale},
+		{"pt-BR", "pt-BR"},
+		{"pt-BR,pt;q=0.9,en;q=0.8", "pt-BR"},
+		{"en;q=0.5,pt-BR;q=0.9", "pt-BR"},
+		{"pt-PT", "pt-BR"},
+		{"fr-FR,fr;q=0.9", DefaultLocale},
+		{"fr,pt;q=0.1", "pt-BR"},
+		{"pt;q=0", DefaultLocale},
+	}
+	for _, test := range tests {
+		t.Run(test.acceptLanguage, func(t *testing.T) {
+			if got := MatchLocale(test.acceptLanguage); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegisterMessages(t *testing.T) {
+	RegisterMessages("x-test", map[string]string{REQUIRED: "{field} is missing"})
+	err := NewErrorByField(REQUIRED, "name", "string")
+	if got, want := err.MessageIn("x-test"), "name is missing"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := NewErrorByField(MIN, "age", "18").MessageIn("x-test"), "'age' field must be at least 18"; got != want {
+		t.Errorf("got %q, want the %s fallback %q", got, DefaultLocale, want)
+	}
+	RegisterMessages("X_TEST", map[string]string{REQUIRED: "{field} is required"})
+	if got, want := err.MessageIn("x-test"), "name is required"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func newMinRule(argument string) Rule {
+	return newNumberRule(MIN, "verify if a value is greater than or equal to %s", argument, func(result int) bool {
+		return result >= 0
+	})
+}
+
+func newMaxRule(argument string) Rule {
+	return newNumberRule(MAX, "verify if a value is less than or equal to %s", argument, func(result int) bool {
+		return result <= 0
+	})
+}
+
+func newGreaterThanRule(argument string) Rule {
+	return newNumberRule(GREATER_THAN, "verify if a value is greater than %s", argument, func(result int) bool {
+		return result > 0
+	})
+}
+
+func newGreaterThanOrEqualRule(argument string) Rule {
+	return newNumberRule(GREATER_THAN_OR_EQUAL, "verify if a value is greater than or equal to %s", argument, func(result int) bool {
+		return result >= 0
+	})
+}
+
+func newLessThanRule(argument string) Rule {
+	return newNumberRule(LESS_THAN, "verify if a value is less than %s", argument, func(result int) bool {
+		return result < 0
+	})
+}
+
+func newLessThanOrEqualRule(argument string) Rule {
+	return newNumberRule(LESS_THAN_OR_EQUAL, "verify if a value is less than or equal to %s", argument, func(result int) bool {
+		return result <= 0
+	})
+}
+
+func newNumberRule(typeName, description, argument string, accept func(result int) bool) Rule {
+	return &rule{
+		typeName:    typeName,
+		description: fmt.Sprintf(description, argument),
+		validator:   numberValidatorFN(argument, accept),
+		argument:    argument,
+	}
+}
+
+func numberValidatorFN(argument string, accept func(result int) bool) validatorFunc {
+	return func(value interface{}) bool {
+		result, ok := compareNumber(value, argument)
+		return ok && accept(result)
+	}
+}
+
+func newBetweenRule(argument string) Rule {
+	return &rule{
+		typeName:    BETWEEN,
+		description: fmt.Sprintf("verify if a value is between %s", strings.Replace(argument, ":", " and ", 1)),
+		validator:   betweenValidatorFN(argument),
+		argument:    argument,
+	}
+}
+
+func betweenValidatorFN(argument string) validatorFunc {
+	min, max, _ := strings.Cut(argument, ":")
+	return func(value interface{}) bool {
+		minResult, minOk := compareNumber(value, min)
+		maxResult, maxOk := compareNumber(value, max)
+		return minOk && maxOk && minResult >= 0 && maxResult <= 0
+	}
+}
+
+// compareNumber compares a numeric value of any kind with the number written
+// in a rule argument, returning -1, 0 or 1. Integers are compared as integers
+// when the argument allows it so int64 values don't lose precision.
+func compareNumber(value interface{}, argument string) (int, bool) {
+	if value == nil {
+		return 0, false
+	}
+	reflection := reflect.ValueOf(value)
+	switch reflection.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if limit, err := strconv.ParseInt(argument, 10, 64); err == nil {
+			return compareOrdered(reflection.Int(), limit), true
+		}
+		return compareFloat(float64(reflection.Int()), argument)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if limit, err := strconv.ParseUint(argument, 10, 64); err == nil {
+			return compareOrdered(reflection.Uint(), limit), true
+		}
+		return compareFloat(float64(reflection.Uint()), argument)
+	case reflect.Float32, reflect.Float64:
+		return compareFloat(reflection.Float(), argument)
+	}
+	return 0, false
+}
+
+func compareFloat(value float64, argument string) (int, bool) {
+	limit, err := strconv.ParseFloat(argument, 64)
+	if err != nil {
+		return 0, false
+	}
+	return compareOrdered(value, limit), true
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func newMinError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, MIN, map[string]string{"min": argument})
+}
+
+func newMaxError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, MAX, map[string]string{"max": argument})
+}
+
+func newGreaterThanError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, GREATER_THAN, map[string]string{"limit": argument})
+}
+
+func newGreaterThanOrEqualError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, GREATER_THAN_OR_EQUAL, map[string]string{"limit": argument})
+}
+
+func newLessThanError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, LESS_THAN, map[string]string{"limit": argument})
+}
+
+func newLessThanOrEqualError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, LESS_THAN_OR_EQUAL, map[string]string{"limit": argument})
+}
+
+func newBetweenError(fieldName, argument string) FieldError {
+	min, max, _ := strings.Cut(argument, ":")
+	return newFieldError(fieldName, BETWEEN, map[string]string{"min": min, "max": max})
+}
@@ -0,0 +1,31 @@
+package rules
+
+import "testing"
+
+func TestNumberRules(t *testing.T) {
+	runRuleTests(t, []ruleTest{
+		{"min=18", float64(18), true},
+		{"min=18", float64(17.9), false},
+		{"min=18", int64(18), true},
+		{"min=18", uint64(17), false},
+		{"min=-1.5", float64(-1.5), true},
+		{"min=-1.5", int64(-2), false},
+		{"max=10", float64(10), true},
+		{"max=10", int(11), false},
+		{"max=9007199254740993", int64(9007199254740993), true},
+		{"max=9007199254740993", int64(9007199254740994), false},
+		{"gt=0", float64(0), false},
+		{"gt=0", float64(0.1), true},
+		{"gte=0", int64(0), true},
+		{"lt=0", int64(-1), true},
+		{"lt=0", int64(0), false},
+		{"lte=0", uint64(0), true},
+		{"between=1:10", float64(1), true},
+		{"between=1:10", float64(10), true},
+		{"between=1:10", float64(10.5), false},
+		{"between=-5:-1", int64(-3), true},
+		{"min=1", "2", false},
+		{"min=1", nil, false},
+		{"max=1", true, false},
+	})
+}
@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+func newOneOfRule(argument string, caseInsensitive bool) Rule {
+	return &rule{
+		typeName:    ONE_OF,
+		description: fmt.Sprintf("verify if a value is one of %s", strings.Join(splitOptions(argument), ", ")),
+		validator:   oneOfValidatorFN(splitOptions(argument), caseInsensitive, true),
+		argument:    argument,
+	}
+}
+
+func newNotOneOfRule(argument string, caseInsensitive bool) Rule {
+	return &rule{
+		typeName:    NOT_ONE_OF,
+		description: fmt.Sprintf("verify if a value isn't one of %s", strings.Join(splitOptions(argument), ", ")),
+		validator:   oneOfValidatorFN(splitOptions(argument), caseInsensitive, false),
+		argument:    argument,
+	}
+}
+
+// splitOptions splits the options of a oneof rule by "|"; "\|" is a pipe
+// that is part of an option.
+func splitOptions(argument string) []string {
+	var options []string
+	var option strings.Builder
+	for i := 0; i < len(argument); i++ {
+		if argument[i] == '\\' && i+1 < len(argument) && argument[i+1] == '|' {
+			option.WriteByte('|')
+			i++
+		} else if argument[i] == '|' {
+			options = append(options, option.String())
+			option.Reset()
+		} else {
+			option.WriteByte(argument[i])
+		}
+	}
+	return append(options, option.String())
+}
+
+func oneOfValidatorFN(options []string, caseInsensitive, mustMatch bool) validatorFunc {
+	return func(value interface{}) bool {
+		if value == nil {
+			return false
+		}
+		if reflect.TypeOf(value).Kind() != reflect.String && !isNumber(value) {
+			return false
+		}
+		for _, option := range options {
+			if matchOption(value, option, caseInsensitive) {
+				return mustMatch
+			}
+		}
+		return !mustMatch
+	}
+}
+
+func matchOption(value interface{}, option string, caseInsensitive bool) bool {
+	if text, ok := value.(string); !ok {
+		result, ok := compareNumber(value, option)
+		return ok && result == 0
+	} else if caseInsensitive {
+		return strings.EqualFold(text, option)
+	} else {
+		return text == option
+	}
+}
+
+func isNumber(value interface{}) bool {
+	_, ok := compareNumber(value, "0")
+	return ok
+}
+
+func newOneOfError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, ONE_OF, map[string]string{"values": strings.Join(splitOptions(argument), ", ")})
+}
+
+func newNotOneOfError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, NOT_ONE_OF, map[string]string{"values": strings.Join(splitOptions(argument), ", ")})
+}
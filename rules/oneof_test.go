@@ -0,0 +1,42 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOneOfRules(t *testing.T) {
+	runRuleTests(t, []ruleTest{
+		{"oneof=admin|user", "admin", true},
+		{"oneof=admin|user", "Admin", false},
+		{"oneof=admin|user", "guest", false},
+		{"oneofci=admin|user", "Admin", true},
+		{"oneof=1|2|3", float64(2), true},
+		{"oneof=1|2|3", int64(4), false},
+		{"oneof=1.5|2", float64(1.5), true},
+		{`oneof=a\|b|c`, "a|b", true},
+		{`oneof=a\|b|c`, "a", false},
+		{"oneof=a|b", nil, false},
+		{"oneof=a|b", true, false},
+		{"notoneof=root|admin", "user", true},
+		{"notoneof=root|admin", "root", false},
+		{"notoneofci=root|admin", "ROOT", false},
+		{"notoneof=1|2", float64(3), true},
+	})
+}
+
+func TestSplitOptions(t *testing.T) {
+	tests := map[string][]string{
+		"a":         {"a"},
+		"a|b":       {"a", "b"},
+		`a\|b|c`:    {"a|b", "c"},
+		"a||b":      {"a", "", "b"},
+		`a\b|c`:     {`a\b`, "c"},
+		`trailing|`: {"trailing", ""},
+	}
+	for argument, want := range tests {
+		if got := splitOptions(argument); !reflect.DeepEqual(got, want) {
+			t.Errorf("splitOptions(%q) = %q, want %q", argument, got, want)
+		}
+	}
+}
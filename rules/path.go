@@ -0,0 +1,44 @@
+package rules
+
+import "strings"
+
+// splitPath splits a field name such as "profiles[2].email" or
+// "metadata[region]" into its segments: ["profiles", "2", "email"]. What is
+// between brackets is a single segment, so map keys such as "example.com"
+// aren't split by their dots.
+func splitPath(name string) []string {
+	if name == "" {
+		return nil // the whole document
+	}
+	var segments []string
+	for name != "" {
+		end := strings.IndexAny(name, ".[")
+		if end < 0 {
+			return append(segments, name)
+		}
+		if key := name[:end]; key != "" || name[end] == '.' {
+			segments = append(segments, key)
+		}
+		if name[end] == '.' {
+			name = name[end+1:]
+			continue
+		}
+		closing := strings.Index(name[end:], "]")
+		if closing < 0 {
+			return append(segments, name[end+1:])
+		}
+		segments = append(segments, name[end+1:end+closing])
+		name = strings.TrimPrefix(name[end+closing+1:], ".")
+	}
+	return segments
+}
+
+// jsonPointer builds the RFC 6901 pointer of the given path segments.
+func jsonPointer(segments []string) string {
+	var pointer strings.Builder
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, segment := range segments {
+		pointer.WriteString("/" + escaper.Replace(segment))
+	}
+	return pointer.String()
+}
@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"", nil},
+		{"email", []string{"email"}},
+		{"address.number", []string{"address", "number"}},
+		{"profiles[2].email", []string{"profiles", "2", "email"}},
+		{"matrix[0][1]", []string{"matrix", "0", "1"}},
+		{"metadata[region]", []string{"metadata", "region"}},
+		{"hosts[example.com]", []string{"hosts", "example.com"}},
+		{"owners[x.y].email", []string{"owners", "x.y", "email"}},
+		{"owners[a[b]", []string{"owners", "a[b"}},
+		{"groups[0][k.v].name", []string{"groups", "0", "k.v", "name"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := splitPath(test.name); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", ""},
+		{"profiles[2].email", "/profiles/2/email"},
+		{"hosts[example.com]", "/hosts/example.com"},
+		{"paths[/tmp]", "/paths/~1tmp"},
+		{"owners[a~b].email", "/owners/a~0b/email"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := NewErrorByField(REQUIRED, test.name, "").Path(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+var patterns sync.Map // expression -> *regexp.Regexp
+
+func newPatternRule(expression string) Rule {
+	compiler := compilePattern(expression)
+	return &rule{
+		typeName:    PATTERN,
+		description: fmt.Sprintf("verify if a value matches the '%s' pattern", expression),
+		validator:   patternValidatorFN(compiler),
+		argument:    expression,
+	}
+}
+
+// compilePattern compiles each expression only once, even when it is shared
+// by fields of different structs. As with regexp.MustCompile, an invalid
+// expression is a programming error and panics.
+func compilePattern(expression string) *regexp.Regexp {
+	if cached, ok := patterns.Load(expression); ok {
+		return cached.(*regexp.Regexp)
+	}
+	compiler, err := regexp.Compile(expression)
+	if err != nil {
+		panic(fmt.Sprintf("rules: invalid pattern '%s': %s", expression, err))
+	}
+	cached, _ := patterns.LoadOrStore(expression, compiler)
+	return cached.(*regexp.Regexp)
+}
+
+func patternValidatorFN(compiler *regexp.Regexp) validatorFunc {
+	return func(value interface{}) bool {
+		if v, ok := value.(string); !ok {
+			return false
+		} else {
+			return compiler.MatchString(v)
+		}
+	}
+}
+
+func newPatternError(fieldName, expression string) FieldError {
+	return newFieldError(fieldName, PATTERN, map[string]string{"pattern": expression})
+}
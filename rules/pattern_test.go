@@ -0,0 +1,30 @@
+package rules
+
+import "testing"
+
+func TestPatternRule(t *testing.T) {
+	runRuleTests(t, []ruleTest{
+		{`pattern=^[a-z]+$`, "abc", true},
+		{`pattern=^[a-z]+$`, "aBc", false},
+		{`pattern=^\d{5}-\d{3}$`, "12345-678", true},
+		{`pattern=^\d{5}-\d{3}$`, "12345678", false},
+		{`pattern=^a,b$`, "a,b", true},
+		{`pattern=^[a-z]+$`, 1, false},
+		{`pattern=^[a-z]+$`, nil, false},
+	})
+}
+
+func TestCompilePatternIsCached(t *testing.T) {
+	if compilePattern(`^cached$`) != compilePattern(`^cached$`) {
+		t.Error("got two compilations of the same expression")
+	}
+}
+
+func TestCompilePatternPanicsOnInvalidExpression(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("an invalid expression didn't panic")
+		}
+	}()
+	GetRuleByHint(`pattern=[a-`)
+}
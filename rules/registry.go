@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// RuleBuilder creates the validation function of a custom rule from the
+// argument informed in the tag ("cpf" has an empty argument, "prefix=abc"
+// has "abc").
+type RuleBuilder func(argument string) func(value interface{}) bool
+
+// ContextRuleBuilder is a RuleBuilder whose validation function also receives
+// the Context of the value, to look up other fields or tell how the value was
+// received (see Context.Presence).
+type ContextRuleBuilder func(argument string) func(value interface{}, ctx Context) bool
+
+// MessageBuilder creates the error message of a custom rule.
+type MessageBuilder func(fieldName, argument string) string
+
+type customRule struct {
+	builder        RuleBuilder
+	contextBuilder ContextRuleBuilder
+	message        MessageBuilder
+}
+
+var reservedRuleNames = []string{
+	REQUIRED, TYPE, LENGTH, MIN_LENGTH, MAX_LENGTH, EMAIL_VALIDATION, DATE_VALIDATION,
+	ARRAY_LEN, ARRAY_MIN_LEN, ARRAY_MAX_LEN, ARRAY_UNIQUE, "len", "minlen", "maxlen",
+	MIN, MAX, GREATER_THAN, GREATER_THAN_OR_EQUAL, LESS_THAN, LESS_THAN_OR_EQUAL, BETWEEN, PATTERN,
+	ONE_OF, NOT_ONE_OF, "oneofci", "notoneofci",
+	EQUAL_FIELD, NOT_EQUAL_FIELD, GREATER_THAN_FIELD, LESS_THAN_FIELD,
+	BEFORE, AFTER, PAST, FUTURE, MIN_AGE, MAX_AGE, "datetime",
+	"required_if", "required_unless", "required_with", "required_without", DECODE, UNKNOWN, NOT_NULL,
+	"ifExists", "omitempty", "nestedProps", "coerce", "dive", "keys", "endkeys", customMessage,
+}
+
+var (
+	customRulesMutex sync.RWMutex
+	customRules      = map[string]customRule{}
+)
+
+// Register makes a custom rule available in the `validate` tag by its name.
+// The message builder is optional; when nil a generic message is used. Like
+// database/sql's Register, it panics if the name is invalid, reserved by a
+// built-in rule or already registered.
+func Register(name string, builder RuleBuilder, message MessageBuilder) {
+	if builder == nil {
+		panic(fmt.Sprintf("rules: nil builder for rule '%s'", name))
+	}
+	register(name, customRule{builder: builder, message: message})
+}
+
+// RegisterWithContext is Register for a rule that receives the Context of the
+// value. Unlike other rules, it also runs when the key is missing or null, so
+// it can tell an absent key, a null and a zero value apart with
+// ctx.Presence(); a rule that only checks present values returns true for
+// the others.
+func RegisterWithContext(name string, builder ContextRuleBuilder, message MessageBuilder) {
+	if builder == nil {
+		panic(fmt.Sprintf("rules: nil builder for rule '%s'", name))
+	}
+	register(name, customRule{contextBuilder: builder, message: message})
+}
+
+func register(name string, custom customRule) {
+	if name == "" || strings.ContainsAny(name, "=, ") {
+		panic(fmt.Sprintf("rules: invalid rule name '%s'", name))
+	}
+	for _, reserved := range reservedRuleNames {
+		if name == reserved {
+			panic(fmt.Sprintf("rules: '%s' is a built-in rule", name))
+		}
+	}
+	customRulesMutex.Lock()
+	defer customRulesMutex.Unlock()
+	if _, exists := customRules[name]; exists {
+		panic(fmt.Sprintf("rules: Register called twice for rule '%s'", name))
+	}
+	customRules[name] = custom
+}
+
+func findCustomRule(name string) (customRule, bool) {
+	customRulesMutex.RLock()
+	defer customRulesMutex.RUnlock()
+	custom, ok := customRules[name]
+	return custom, ok
+}
+
+func findCustomRuleByHint(hint string) Rule {
+	name, argument, _ := strings.Cut(hint, "=")
+	custom, ok := findCustomRule(name)
+	if !ok {
+		return nil
+	}
+	r := &rule{
+		typeName:    name,
+		description: fmt.Sprintf("verify if a value satisfies the custom '%s' rule", name),
+		argument:    argument,
+	}
+	if custom.contextBuilder != nil {
+		r.contextValidator = contextValidatorFunc(custom.contextBuilder(argument))
+		r.validator = func(value interface{}) bool { return r.contextValidator(value, nil) }
+		r.validatesMissing = true
+	} else {
+		r.validator = validatorFunc(custom.builder(argument))
+	}
+	return r
+}
+
+func newCustomRuleError(ruleType, fieldName, argument string) FieldError {
+	custom, ok := findCustomRule(ruleType)
+	if !ok {
+		return nil
+	}
+	fieldError := newKeyedFieldError(fieldName, ruleType, ruleType, map[string]string{"rule": ruleType, "argument": argument})
+	if custom.message != nil {
+		fieldError.fallback = custom.message(fieldName, argument)
+	} else {
+		fieldError.keys = append(fieldError.keys, customMessage)
+	}
+	return fieldError
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+func init() {
+	Register("x-slug", func(argument string) func(value interface{}) bool {
+		return func(value interface{}) bool {
+			text, ok := value.(string)
+			return ok && text != "" && strings.Trim(text, "abcdefghijklmnopqrstuvwxyz0123456789-") == ""
+		}
+	}, nil)
+	Register("x-prefix", func(argument string) func(value interface{}) bool {
+		return func(value interface{}) bool {
+			text, ok := value.(string)
+			return ok && strings.HasPrefix(text, argument)
+		}
+	}, func(fieldName, argument string) string {
+		return "the '" + fieldName + "' field must start with '" + argument + "'"
+	})
+}
+
+func TestCustomRules(t *testing.T) {
+	runRuleTests(t, []ruleTest{
+		{"x-slug", "my-post-1", true},
+		{"x-slug", "My Post", false},
+		{"x-slug", 1, false},
+		{"x-prefix=SKU-", "SKU-1", true},
+		{"x-prefix=SKU-", "1", false},
+	})
+}
+
+func TestCustomRuleErrors(t *testing.T) {
+	tests := []struct {
+		hint    string
+		message string
+	}{
+		{"x-slug", "the value provided for the 'slug' field doesn't satisfy the 'x-slug' rule"},
+		{"x-prefix=SKU-", "the 'slug' field must start with 'SKU-'"},
+	}
+	for _, test := range tests {
+		t.Run(test.hint, func(t *testing.T) {
+			rule := GetRuleByHint(test.hint)
+			err := rule.GenerateError("slug")
+			if err.RuleType() != rule.Type() || err.Argument() != rule.Argument() || err.Message() != test.message {
+				t.Errorf("got %s with %q: %q", err.RuleType(), err.Argument(), err.Message())
+			}
+		})
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	valid := func(argument string) func(value interface{}) bool {
+		return func(value interface{}) bool { return true }
+	}
+	tests := []struct {
+		name    string
+		rule    string
+		builder RuleBuilder
+	}{
+		{"empty name", "", valid},
+		{"name with argument", "a=b", valid},
+		{"name with comma", "a,b", valid},
+		{"nil builder", "x-nil", nil},
+		{"built-in rule", EMAIL_VALIDATION, valid},
+		{"reserved hint", "dive", valid},
+		{"registered twice", "x-slug", valid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) didn't panic", test.rule)
+				}
+			}()
+			Register(test.rule, test.builder, nil)
+		})
+	}
+}
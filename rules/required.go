@@ -3,6 +3,7 @@ package rules
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 func NewRequiredRule(argument string) Rule {
@@ -31,6 +32,99 @@ func validateExists(value interface{}) bool {
 }
 
 func newRequiredError(fieldName, fieldType string) FieldError {
-	message := fmt.Sprintf("'%s' field of type '%s' is missing or empty", fieldName, fieldType)
-	return newFieldError(fieldName, message, REQUIRED)
+	return newFieldError(fieldName, REQUIRED, map[string]string{"type": fieldType})
+}
+
+// RequiredIf
+func newRequiredIfRule(argument string) Rule {
+	otherField, options, _ := strings.Cut(argument, ":")
+	return newConditionalRequiredRule(argument, newRequiredIfError, func(ctx Context) bool {
+		other, ok := ctx.Lookup(otherField)
+		return ok && matchAnyOption(other, splitOptions(options))
+	})
+}
+
+func newRequiredIfError(fieldName, argument string) FieldError {
+	return newConditionalRequiredError(fieldName, argument, requiredIfMessage, requiredIfAnyMessage)
+}
+
+// RequiredUnless
+func newRequiredUnlessRule(argument string) Rule {
+	otherField, options, _ := strings.Cut(argument, ":")
+	return newConditionalRequiredRule(argument, newRequiredUnlessError, func(ctx Context) bool {
+		other, ok := ctx.Lookup(otherField)
+		return !ok || !matchAnyOption(other, splitOptions(options))
+	})
+}
+
+func newRequiredUnlessError(fieldName, argument string) FieldError {
+	return newConditionalRequiredError(fieldName, argument, requiredUnlessMessage, requiredUnlessAnyMessage)
+}
+
+// RequiredWith
+func newRequiredWithRule(otherField string) Rule {
+	return newConditionalRequiredRule(otherField, newRequiredWithError, func(ctx Context) bool {
+		other, ok := ctx.Lookup(otherField)
+		return ok && validateExists(other)
+	})
+}
+
+func newRequiredWithError(fieldName, otherField string) FieldError {
+	return newKeyedFieldError(fieldName, REQUIRED, requiredWithMessage, map[string]string{"other": otherField})
+}
+
+// RequiredWithout
+func newRequiredWithoutRule(otherField string) Rule {
+	return newConditionalRequiredRule(otherField, newRequiredWithoutError, func(ctx Context) bool {
+		other, ok := ctx.Lookup(otherField)
+		return !ok || !validateExists(other)
+	})
+}
+
+func newRequiredWithoutError(fieldName, otherField string) FieldError {
+	return newKeyedFieldError(fieldName, REQUIRED, requiredWithoutMessage, map[string]string{"other": otherField})
+}
+
+// newConditionalRequiredRule builds a REQUIRED rule that only checks the value
+// when the condition over the other fields is met.
+func newConditionalRequiredRule(argument string, errorBuilder func(fieldName, argument string) FieldError, condition func(ctx Context) bool) Rule {
+	return &rule{
+		typeName:    REQUIRED,
+		description: "verify if a value exists when a condition over other fields is met",
+		contextValidator: func(value interface{}, ctx Context) bool {
+			if ctx == nil || !condition(ctx) {
+				return true
+			}
+			return validateExists(value)
+		},
+		errorBuilder: errorBuilder,
+		argument:     argument,
+	}
+}
+
+func matchAnyOption(value interface{}, options []string) bool {
+	for _, option := range options {
+		if matchOption(value, option, false) || fmt.Sprint(value) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// newConditionalRequiredError uses the singleKey message when the condition
+// has a single value and the anyKey message when it lists several.
+func newConditionalRequiredError(fieldName, argument, singleKey, anyKey string) FieldError {
+	otherField, options, _ := strings.Cut(argument, ":")
+	values := splitOptions(options)
+	params := map[string]string{"other": otherField, "value": options, "values": strings.Join(values, ", ")}
+	if len(values) > 1 {
+		return newKeyedFieldError(fieldName, REQUIRED, anyKey, params)
+	}
+	return newKeyedFieldError(fieldName, REQUIRED, singleKey, params)
+}
+
+// newNotNullError reports an explicit null in a field tagged with
+// `nullable:"false"`.
+func newNotNullError(fieldName string) FieldError {
+	return newFieldError(fieldName, NOT_NULL, nil)
 }
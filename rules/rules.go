@@ -2,21 +2,65 @@ package rules
 
 type validatorFunc func(value interface{}) bool
 
+type contextValidatorFunc func(value interface{}, ctx Context) bool
+
+// Context gives rules access to the values around the validated one. Paths
+// are relative to the object that holds the field ("password") or, when
+// prefixed with "$.", to the root of the data ("$.account.password").
+type Context interface {
+	Root() map[string]interface{}
+	Parent() map[string]interface{}
+	Lookup(path string) (interface{}, bool)
+	DateFormat() string
+	Presence() Presence
+}
+
+// Presence tells how the validated value was received, since a nil value
+// may be a missing key or an explicit null, and a zero value (such as "" or
+// 0) is still a present one.
+type Presence int
+
+const (
+	Absent  Presence = iota // the key isn't in the data
+	Null                    // the key is in the data with null
+	Present                 // the key is in the data with a value
+)
+
 type Rule interface {
 	Type() string
 	Description() string
 	Validator() validatorFunc
 	Argument() string
 	IsValid(value interface{}) bool
+	IsValidWithContext(value interface{}, ctx Context) bool
 	GenerateError(fieldName string) FieldError
 	IsSliceRule() bool
 }
 
+// MultiErrorRule is a Rule that may find several errors in a single value,
+// such as each duplicate element of slice:unique. When the value isn't valid,
+// GenerateErrors is used instead of GenerateError.
+type MultiErrorRule interface {
+	Rule
+	GenerateErrors(fieldName string, value interface{}) []FieldError
+}
+
+// MissingValueRule is a Rule that may also run when the value is missing or
+// null, which the other rules (except the required ones) skip. Rules
+// registered with RegisterWithContext do, so they can see every Presence.
+type MissingValueRule interface {
+	Rule
+	ValidatesMissingValue() bool
+}
+
 type rule struct {
-	typeName    string
-	description string
-	validator   validatorFunc
-	argument    string
+	typeName         string
+	description      string
+	validator        validatorFunc
+	contextValidator contextValidatorFunc
+	errorBuilder     func(fieldName, argument string) FieldError
+	argument         string
+	validatesMissing bool
 }
 
 const (
@@ -30,6 +74,35 @@ const (
 	ARRAY_LEN        = "slice:len"
 	ARRAY_MIN_LEN    = "slice:minlen"
 	ARRAY_MAX_LEN    = "slice:maxlen"
+	ARRAY_UNIQUE     = "slice:unique"
+
+	MIN                   = "min"
+	MAX                   = "max"
+	GREATER_THAN          = "gt"
+	GREATER_THAN_OR_EQUAL = "gte"
+	LESS_THAN             = "lt"
+	LESS_THAN_OR_EQUAL    = "lte"
+	BETWEEN               = "between"
+
+	PATTERN    = "pattern"
+	ONE_OF     = "oneof"
+	NOT_ONE_OF = "notoneof"
+
+	EQUAL_FIELD        = "eqfield"
+	NOT_EQUAL_FIELD    = "nefield"
+	GREATER_THAN_FIELD = "gtfield"
+	LESS_THAN_FIELD    = "ltfield"
+
+	BEFORE  = "before"
+	AFTER   = "after"
+	PAST    = "past"
+	FUTURE  = "future"
+	MIN_AGE = "minage"
+	MAX_AGE = "maxage"
+
+	DECODE   = "decode"
+	UNKNOWN  = "unknown"
+	NOT_NULL = "notnull"
 )
 
 func (r *rule) Type() string {
@@ -49,13 +122,27 @@ func (r *rule) Argument() string {
 }
 
 func (r *rule) IsValid(value interface{}) bool {
+	return r.IsValidWithContext(value, nil)
+}
+
+func (r *rule) IsValidWithContext(value interface{}, ctx Context) bool {
+	if r.contextValidator != nil {
+		return r.contextValidator(value, ctx)
+	}
 	return r.validator(value)
 }
 
 func (r *rule) GenerateError(fieldName string) FieldError {
+	if r.errorBuilder != nil {
+		return withArgument(r.errorBuilder(fieldName, r.argument), r.argument)
+	}
 	return NewErrorByField(r.typeName, fieldName, r.argument)
 }
 
+func (r *rule) ValidatesMissingValue() bool {
+	return r.validatesMissing || r.typeName == REQUIRED
+}
+
 func (r *rule) IsSliceRule() bool {
-	return r.typeName == ARRAY_LEN || r.typeName == ARRAY_MIN_LEN || r.typeName == ARRAY_MAX_LEN
+	return r.typeName == ARRAY_LEN || r.typeName == ARRAY_MIN_LEN || r.typeName == ARRAY_MAX_LEN || r.typeName == ARRAY_UNIQUE
 }
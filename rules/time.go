@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func newBeforeRule(argument string) Rule {
+	limit := parseTimeArgument(BEFORE, argument)
+	return newTimeRule(BEFORE, "verify if a date is before "+argument, argument, func(value time.Time) bool {
+		return value.Before(limit)
+	})
+}
+
+func newAfterRule(argument string) Rule {
+	limit := parseTimeArgument(AFTER, argument)
+	return newTimeRule(AFTER, "verify if a date is after "+argument, argument, func(value time.Time) bool {
+		return value.After(limit)
+	})
+}
+
+func newPastRule(string) Rule {
+	return newTimeRule(PAST, "verify if a date is in the past", "", func(value time.Time) bool {
+		return value.Before(time.Now())
+	})
+}
+
+func newFutureRule(string) Rule {
+	return newTimeRule(FUTURE, "verify if a date is in the future", "", func(value time.Time) bool {
+		return value.After(time.Now())
+	})
+}
+
+// newMinAgeRule accepts dates at least the given number of years ago, such
+// as the birth date of someone who is 18 or older.
+func newMinAgeRule(argument string) Rule {
+	years, _ := strconv.Atoi(argument)
+	return newTimeRule(MIN_AGE, fmt.Sprintf("verify if a date is at least %s years ago", argument), argument, func(value time.Time) bool {
+		return !value.AddDate(years, 0, 0).After(time.Now())
+	})
+}
+
+// newMaxAgeRule accepts dates at most the given number of (whole) years ago.
+func newMaxAgeRule(argument string) Rule {
+	years, _ := strconv.Atoi(argument)
+	return newTimeRule(MAX_AGE, fmt.Sprintf("verify if a date is at most %s years ago", argument), argument, func(value time.Time) bool {
+		return value.AddDate(years+1, 0, 0).After(time.Now())
+	})
+}
+
+func newTimeRule(typeName, description, argument string, accept func(value time.Time) bool) Rule {
+	return &rule{
+		typeName:         typeName,
+		description:      description,
+		contextValidator: timeValidatorFN(accept),
+		argument:         argument,
+	}
+}
+
+func timeValidatorFN(accept func(value time.Time) bool) contextValidatorFunc {
+	return func(value interface{}, ctx Context) bool {
+		format := ""
+		if ctx != nil {
+			format = ctx.DateFormat()
+		}
+		date, ok := timeOf(value, format)
+		return ok && accept(date)
+	}
+}
+
+// timeOf reads a date received as a time.Time or as a string in the field's
+// date format, or in RFC 3339 when the field has no date rule.
+func timeOf(value interface{}, format string) (time.Time, bool) {
+	switch date := value.(type) {
+	case time.Time:
+		return date, true
+	case string:
+		if format == "" {
+			format = time.RFC3339
+		}
+		parsed, err := time.Parse(format, date)
+		return parsed, err == nil
+	}
+	return time.Time{}, false
+}
+
+// parseTimeArgument reads the date of a before/after rule, written in RFC
+// 3339 or as a plain date. Like regexp.MustCompile, it panics on a malformed
+// date, since the tag is part of the code.
+func parseTimeArgument(typeName, argument string) time.Time {
+	for _, format := range []string{time.RFC3339, defaultDateFormat} {
+		if date, err := time.Parse(format, argument); err == nil {
+			return date
+		}
+	}
+	panic(fmt.Sprintf("rules: invalid date '%s' in the %s rule", argument, typeName))
+}
+
+func newBeforeError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, BEFORE, map[string]string{"date": argument})
+}
+
+func newAfterError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, AFTER, map[string]string{"date": argument})
+}
+
+func newPastError(fieldName string) FieldError {
+	return newFieldError(fieldName, PAST, nil)
+}
+
+func newFutureError(fieldName string) FieldError {
+	return newFieldError(fieldName, FUTURE, nil)
+}
+
+func newMinAgeError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, MIN_AGE, map[string]string{"age": argument})
+}
+
+func newMaxAgeError(fieldName, argument string) FieldError {
+	return newFieldError(fieldName, MAX_AGE, map[string]string{"age": argument})
+}
@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeRules(t *testing.T) {
+	now := time.Now()
+	runRuleTests(t, []ruleTest{
+		{"before=2000-01-01", time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC), true},
+		{"before=2000-01-01", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"before=2000-01-01", "1999-12-31T23:59:59Z", true},
+		{"before=2000-01-01T12:00:00Z", "2000-01-01T11:00:00Z", true},
+		{"after=2000-01-01", time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		{"after=2000-01-01", time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"past", now.Add(-time.Minute), true},
+		{"past", now.Add(time.Hour), false},
+		{"future", now.Add(time.Hour), true},
+		{"future", now.Add(-time.Minute), false},
+		{"minage=18", now.AddDate(-18, 0, -1), true},
+		{"minage=18", now.AddDate(-17, 0, 0), false},
+		{"maxage=65", now.AddDate(-65, 0, 1), true},
+		{"maxage=65", now.AddDate(-66, 0, 0), false},
+		{"past", "not a date", false},
+		{"past", float64(1), false},
+		{"past", nil, false},
+	})
+}
+
+func TestTimeRulesUseTheDateFormat(t *testing.T) {
+	rule := GetRuleByHint("before=2000-01-01")
+	ctx := dateFormatContext("02/01/2006")
+	if !rule.IsValidWithContext("31/12/1999", ctx) {
+		t.Error("a date in the field's format wasn't read")
+	}
+	if rule.IsValidWithContext("1999-12-31T00:00:00Z", ctx) {
+		t.Error("a date in another format was accepted")
+	}
+}
+
+func TestTimeRulePanicsOnInvalidDate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("an invalid date didn't panic")
+		}
+	}()
+	GetRuleByHint("before=yesterday")
+}
+
+type dateFormatContext string
+
+func (c dateFormatContext) Root() map[string]interface{}           { return nil }
+func (c dateFormatContext) Parent() map[string]interface{}         { return nil }
+func (c dateFormatContext) Lookup(path string) (interface{}, bool) { return nil, false }
+func (c dateFormatContext) DateFormat() string                     { return string(c) }
+func (c dateFormatContext) Presence() Presence                     { return Present }
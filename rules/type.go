@@ -57,8 +57,7 @@ func newWrongTypeError(fieldName, fieldType string) FieldError {
 	if fieldType == "struct" {
 		fieldType = "json"
 	}
-	message := fmt.Sprintf("'%s' field type must be '%s'", fieldName, fieldType)
-	return newFieldError(fieldName, message, TYPE)
+	return newFieldError(fieldName, TYPE, map[string]string{"type": fieldType})
 }
 
 func validateType[T comparable](value interface{}) bool {
@@ -0,0 +1,7 @@
+package rules
+
+// newUnknownFieldError reports a key of the data that isn't mapped to any
+// field of the struct, found by the validator's strict mode.
+func newUnknownFieldError(fieldName string) FieldError {
+	return newFieldError(fieldName, UNKNOWN, nil)
+}
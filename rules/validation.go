@@ -2,6 +2,7 @@ package rules
 
 import (
 	"strconv"
+	"time"
 )
 
 const (
@@ -9,10 +10,20 @@ const (
 )
 
 func GetRuleByHint(hint string) Rule {
-	if validator := findLengthRuleByHint(hint); validator != nil {
+	if validator := findRequiredRuleByHint(hint); validator != nil {
+		return validator
+	} else if validator := findLengthRuleByHint(hint); validator != nil {
+		return validator
+	} else if validator := findNumberRuleByHint(hint); validator != nil {
+		return validator
+	} else if validator := findFieldRuleByHint(hint); validator != nil {
+		return validator
+	} else if validator := findTimeRuleByHint(hint); validator != nil {
 		return validator
 	} else if validator := findValidationRuleByHint(hint); validator != nil {
 		return validator
+	} else if validator := findCustomRuleByHint(hint); validator != nil {
+		return validator
 	}
 	return nil
 }
@@ -37,6 +48,46 @@ func findLengthRuleByHint(hint string) Rule {
 	return ruleBuilder(value)
 }
 
+func findRequiredRuleByHint(hint string) Rule {
+	for compiler, builderFn := range requiredCompilerRuleBuilder {
+		if compiler.Match([]byte(hint)) {
+			return builderFn(compiler.FindStringSubmatch(hint)[1])
+		}
+	}
+	return nil
+}
+
+func findNumberRuleByHint(hint string) Rule {
+	for compiler, builderFn := range numberCompilerRuleBuilder {
+		if compiler.Match([]byte(hint)) {
+			return builderFn(compiler.FindStringSubmatch(hint)[1])
+		}
+	}
+	return nil
+}
+
+func findFieldRuleByHint(hint string) Rule {
+	for compiler, builderFn := range fieldCompilerRuleBuilder {
+		if compiler.Match([]byte(hint)) {
+			return builderFn(compiler.FindStringSubmatch(hint)[1])
+		}
+	}
+	return nil
+}
+
+func findTimeRuleByHint(hint string) Rule {
+	for compiler, builderFn := range timeCompilerRuleBuilder {
+		if matches := compiler.FindStringSubmatch(hint); matches != nil {
+			argument := ""
+			if len(matches) > 1 {
+				argument = matches[1]
+			}
+			return builderFn(argument)
+		}
+	}
+	return nil
+}
+
 func findValidationRuleByHint(hint string) Rule {
 	var rule Rule
 	if emailRuleCompiler.Match([]byte(hint)) {
@@ -47,6 +98,23 @@ func findValidationRuleByHint(hint string) Rule {
 			format = defaultDateFormat
 		}
 		rule = validateDateRule(format)
+	} else if dateTimeRuleCompiler.Match([]byte(hint)) {
+		format := dateTimeRuleCompiler.FindStringSubmatch(hint)[1]
+		if format == "" {
+			format = time.RFC3339
+		}
+		rule = validateDateRule(format)
+	} else if arrayUniqueRuleCompiler.Match([]byte(hint)) {
+		rule = newArrayUniqueRule(arrayUniqueRuleCompiler.FindStringSubmatch(hint)[1])
+	} else if patternRuleCompiler.Match([]byte(hint)) {
+		rule = newPatternRule(patternRuleCompiler.FindStringSubmatch(hint)[1])
+	} else if oneOfRuleCompiler.Match([]byte(hint)) {
+		matches := oneOfRuleCompiler.FindStringSubmatch(hint)
+		if matches[1] == "" {
+			rule = newOneOfRule(matches[3], matches[2] != "")
+		} else {
+			rule = newNotOneOfRule(matches[3], matches[2] != "")
+		}
 	}
 	if rule == nil {
 		return nil
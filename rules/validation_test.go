@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+)
+
+// ruleTest is a value that a rule, given by its hint, must accept or reject.
+type ruleTest struct {
+	hint  string
+	value interface{}
+	want  bool
+}
+
+func (test ruleTest) String() string {
+	return fmt.Sprintf("%s/%T(%v)", test.hint, test.value, test.value)
+}
+
+func runRuleTests(t *testing.T, tests []ruleTest) {
+	t.Helper()
+	for _, test := range tests {
+		t.Run(test.String(), func(t *testing.T) {
+			rule := GetRuleByHint(test.hint)
+			if rule == nil {
+				t.Fatalf("no rule for hint %q", test.hint)
+			}
+			if got := rule.IsValid(test.value); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetRuleByHint(t *testing.T) {
+	tests := []struct {
+		hint     string
+		ruleType string
+		argument string
+	}{
+		{"required_if=kind:company", REQUIRED, "kind:company"},
+		{"len=3", LENGTH, "3"},
+		{"minlen=3", MIN_LENGTH, "3"},
+		{"maxlen=3", MAX_LENGTH, "3"},
+		{"slice:len=2", ARRAY_LEN, "2"},
+		{"slice:unique", ARRAY_UNIQUE, ""},
+		{"slice:unique=sku", ARRAY_UNIQUE, "sku"},
+		{"min=-1.5", MIN, "-1.5"},
+		{"between=1:10", BETWEEN, "1:10"},
+		{"pattern=^[a-z]+$", PATTERN, "^[a-z]+$"},
+		{"oneof=a|b", ONE_OF, "a|b"},
+		{"notoneofci=a|b", NOT_ONE_OF, "a|b"},
+		{"eqfield=password", EQUAL_FIELD, "password"},
+		{"before=2000-01-01", BEFORE, "2000-01-01"},
+		{"past", PAST, ""},
+		{"minage=18", MIN_AGE, "18"},
+		{"email", EMAIL_VALIDATION, ""},
+		{"date", DATE_VALIDATION, defaultDateFormat},
+		{"date=02/01/2006", DATE_VALIDATION, "02/01/2006"},
+		{"datetime", DATE_VALIDATION, "2006-01-02T15:04:05Z07:00"},
+	}
+	for _, test := range tests {
+		t.Run(test.hint, func(t *testing.T) {
+			rule := GetRuleByHint(test.hint)
+			if rule == nil {
+				t.Fatalf("no rule for hint %q", test.hint)
+			}
+			if rule.Type() != test.ruleType || rule.Argument() != test.argument {
+				t.Errorf("got %s with %q, want %s with %q", rule.Type(), rule.Argument(), test.ruleType, test.argument)
+			}
+		})
+	}
+}
+
+func TestGetRuleByHintUnknown(t *testing.T) {
+	for _, hint := range []string{"", "maxlength=3", "min=abc", "unknown"} {
+		if rule := GetRuleByHint(hint); rule != nil {
+			t.Errorf("got %s for hint %q, want nil", rule.Type(), hint)
+		}
+	}
+}
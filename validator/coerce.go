@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// coerceFields converts, in place, the strings received for the fields that
+// must be coerced, so the rules of every field (including cross-field ones)
+// see the converted values. It returns the rules.TYPE errors of the values
+// that couldn't be converted and the names of their fields.
+func coerceFields(data map[string]interface{}, fields []Field, prefix string, opts options) ([]rules.FieldError, map[string]bool) {
+	var errs []rules.FieldError
+	var failed map[string]bool
+	for _, field := range fields {
+		if !opts.coerce && !field.Coerce() {
+			continue
+		}
+		value := field.ExtractValueFrom(data)
+		if value == nil {
+			continue
+		}
+		coerced, ok := coerceValue(value, field.ReflectType(), field.DateFormat())
+		if !ok {
+			if failed == nil {
+				failed = map[string]bool{}
+			}
+			failed[field.Name()] = true
+			errs = append(errs, NewTypeError(prefix+field.Name(), field.ReflectType(), value))
+			continue
+		}
+		setPath(data, field.Name(), coerced)
+	}
+	return errs, failed
+}
+
+// coerceTime replaces a date received as a string in a time.Time field (or a
+// pointer or slice of it) by the parsed time, so the field can be built even
+// when its `date` format isn't RFC 3339. It runs after the rules of the field
+// passed, so they see the date as it was received.
+func coerceTime(data map[string]interface{}, field Field, name string, value interface{}) []rules.FieldError {
+	if value == nil || !isTimeType(field.ReflectType()) {
+		return nil
+	}
+	coerced, ok := coerceValue(value, field.ReflectType(), field.DateFormat())
+	if !ok {
+		return []rules.FieldError{NewTypeError(name, field.ReflectType(), value)}
+	}
+	setPath(data, field.Name(), coerced)
+	return nil
+}
+
+// isTimeType tells if t is time.Time, a pointer to it or a slice of them.
+func isTimeType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t == timeType
+}
+
+// coerceValue converts a string to t, or each string of a slice to the
+// element type of t (see CoerceString). Values that are already of another
+// JSON type, or strings for a type that can't be converted from one, are
+// kept, leaving them to the `type` rule.
+func coerceValue(value interface{}, t reflect.Type, dateFormat string) (interface{}, bool) {
+	t = derefType(t)
+	if items, ok := value.([]interface{}); ok && t.Kind() == reflect.Slice {
+		coerced := make([]interface{}, len(items))
+		for i, item := range items {
+			if coerced[i], ok = coerceValue(item, t.Elem(), dateFormat); !ok {
+				return value, false
+			}
+		}
+		return coerced, true
+	}
+	text, ok := value.(string)
+	if !ok {
+		return value, true
+	}
+	coerced, err := CoerceString(text, t, dateFormat)
+	if errors.Is(err, errUnsupportedType) {
+		return value, true
+	} else if err != nil {
+		return value, false
+	}
+	if t == timeType {
+		return coerced.Interface(), true // kept parsed, unlike in valueToData
+	}
+	data, _ := valueToData(coerced, map[visitedValue]bool{}) // a converted string has no cycles
+	return data, true
+}
+
+var errUnsupportedType = errors.New("unsupported type")
+
+// CoerceString converts a string received for a field, such as a query
+// parameter or the value of a `coerce` field, to its Go type t: booleans,
+// numbers, dates (in dateFormat or, when empty, RFC 3339), types implementing
+// encoding.TextUnmarshaler and pointers to any of them. Spaces around numbers,
+// booleans and dates are ignored; strings are kept as received.
+func CoerceString(text string, t reflect.Type, dateFormat string) (reflect.Value, error) {
+	if t.Kind() == reflect.Ptr {
+		value, err := CoerceString(text, t.Elem(), dateFormat)
+		if err != nil {
+			return value, err
+		}
+		pointer := reflect.New(t.Elem())
+		pointer.Elem().Set(value)
+		return pointer, nil
+	}
+	if t == timeType {
+		if dateFormat == "" {
+			dateFormat = time.RFC3339
+		}
+		date, err := time.Parse(dateFormat, strings.TrimSpace(text))
+		return reflect.ValueOf(date), err
+	}
+	result := reflect.New(t).Elem()
+	if unmarshaler, ok := result.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return result, unmarshaler.UnmarshalText([]byte(text))
+	}
+	trimmed := strings.TrimSpace(text)
+	switch t.Kind() {
+	case reflect.String:
+		result.SetString(text)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(trimmed)
+		if err != nil {
+			return result, err
+		}
+		result.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(trimmed, 10, t.Bits())
+		if err != nil {
+			return result, err
+		}
+		result.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(trimmed, 10, t.Bits())
+		if err != nil {
+			return result, err
+		}
+		result.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(trimmed, t.Bits())
+		if err != nil {
+			return result, err
+		}
+		result.SetFloat(parsed)
+	default:
+		return result, fmt.Errorf("%w %s", errUnsupportedType, t)
+	}
+	return result, nil
+}
+
+// NewTypeError reports a value received for a field that can't be converted
+// to the field's Go type t, such as "abc" for an int.
+func NewTypeError(name string, t reflect.Type, value interface{}) rules.FieldError {
+	return rules.WithValue(rules.NewErrorByField(rules.TYPE, name, coercedTypeName(t)), value)
+}
+
+func coercedTypeName(t reflect.Type) string {
+	t = derefType(t)
+	if t.Kind() == reflect.Slice {
+		return "[]" + coercedTypeName(t.Elem())
+	}
+	if t == timeType {
+		return t.String()
+	}
+	return t.Kind().String()
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCoerceString(t *testing.T) {
+	number := 42
+	tests := []struct {
+		name       string
+		text       string
+		t          reflect.Type
+		dateFormat string
+		want       interface{}
+		wantErr    bool
+	}{
+		{"string kept as received", " a ", reflect.TypeOf(""), "", " a ", false},
+		{"int", " 42 ", reflect.TypeOf(0), "", 42, false},
+		{"int overflow", "300", reflect.TypeOf(int8(0)), "", int8(0), true},
+		{"uint", "7", reflect.TypeOf(uint(0)), "", uint(7), false},
+		{"negative uint", "-7", reflect.TypeOf(uint(0)), "", uint(0), true},
+		{"float", "9.5", reflect.TypeOf(0.0), "", 9.5, false},
+		{"bool", "true", reflect.TypeOf(false), "", true, false},
+		{"invalid bool", "yes", reflect.TypeOf(false), "", false, true},
+		{"pointer", "42", reflect.TypeOf(&number), "", &number, false},
+		{"RFC 3339 date", "2000-01-31T00:00:00Z", reflect.TypeOf(time.Time{}), "", time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC), false},
+		{"date in format", "31/01/2000", reflect.TypeOf(time.Time{}), "02/01/2006", time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC), false},
+		{"text unmarshaler", "127.0.0.1", reflect.TypeOf(net.IP{}), "", net.ParseIP("127.0.0.1"), false},
+		{"invalid text unmarshaler", "localhost", reflect.TypeOf(net.IP{}), "", net.IP(nil), true},
+		{"unsupported", "a", reflect.TypeOf(struct{}{}), "", struct{}{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := CoerceString(test.text, test.t, test.dateFormat)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(got.Interface(), test.want) {
+				t.Errorf("got %#v, want %#v", got.Interface(), test.want)
+			}
+		})
+	}
+}
+
+type coercionDTO struct {
+	Age    int       `json:"age" validate:"required,min=18"`
+	Active bool      `json:"active"`
+	Ids    []int     `json:"ids"`
+	Born   time.Time `json:"born" validate:"date=2006-01-02"`
+	Score  float64   `json:"score" validate:"coerce,max=10"`
+	Name   string    `json:"name"`
+}
+
+func TestValidateDTOWithCoercion(t *testing.T) {
+	row := map[string]interface{}{"age": "20", "active": "true", "ids": []string{"1", "2"}, "born": "2000-01-31", "score": "9.5", "name": " a "}
+	dto, err := ValidateDTO[coercionDTO](row, WithCoercion())
+	if err != nil {
+		t.Fatalf("unexpected errors: %v", errorsOf(err))
+	}
+	want := coercionDTO{20, true, []int{1, 2}, time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC), 9.5, " a "}
+	if !reflect.DeepEqual(*dto, want) {
+		t.Errorf("got %+v, want %+v", *dto, want)
+	}
+}
+
+func TestValidateDTOCoercionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		opts []Option
+		want []string
+	}{
+		{"not a number", map[string]interface{}{"age": "x"}, []Option{WithCoercion()}, []string{"age:type"}},
+		{"rules after coercion", map[string]interface{}{"age": "17"}, []Option{WithCoercion()}, []string{"age:min"}},
+		{"slice element", map[string]interface{}{"age": 20, "ids": []string{"1", "x"}}, []Option{WithCoercion()}, []string{"ids:type"}},
+		{"coerce hint", map[string]interface{}{"age": 20, "score": "11"}, nil, []string{"score:max"}},
+		{"without coercion", map[string]interface{}{"age": "20"}, nil, []string{"age:type"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateDTO[coercionDTO](test.data, test.opts...)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+const rootPathPrefix = "$."
+
+// fieldContext implements rules.Context for a field being validated.
+type fieldContext struct {
+	root       map[string]interface{}
+	parent     map[string]interface{}
+	dateFormat string
+	presence   rules.Presence
+}
+
+func newFieldContext(root, data map[string]interface{}, field Field, presence rules.Presence) rules.Context {
+	parent := data
+	if index := strings.LastIndex(field.Name(), fieldDelimiter); index >= 0 {
+		parent, _ = extractPath(data, field.Name()[:index]).(map[string]interface{})
+	}
+	return &fieldContext{root, parent, field.DateFormat(), presence}
+}
+
+func (c *fieldContext) Root() map[string]interface{} {
+	return c.root
+}
+
+func (c *fieldContext) Parent() map[string]interface{} {
+	return c.parent
+}
+
+func (c *fieldContext) Lookup(path string) (interface{}, bool) {
+	var value interface{}
+	if strings.HasPrefix(path, rootPathPrefix) {
+		value = extractPath(c.root, strings.TrimPrefix(path, rootPathPrefix))
+	} else {
+		value = extractPath(c.parent, path)
+	}
+	return value, value != nil
+}
+
+func (c *fieldContext) DateFormat() string {
+	return c.dateFormat
+}
+
+func (c *fieldContext) Presence() rules.Presence {
+	return c.presence
+}
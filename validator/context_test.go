@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type signupDTO struct {
+	Password             string `json:"password" validate:"required"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=password"`
+	Nickname             string `json:"nickname" validate:"nefield=password"`
+	StartDate            string `json:"start_date" validate:"date=02/01/2006"`
+	EndDate              string `json:"end_date" validate:"date=02/01/2006,gtfield=start_date"`
+	Min                  int    `json:"min"`
+	Max                  int    `json:"max" validate:"gtfield=min"`
+	Account              struct {
+		Password string `json:"password" validate:"eqfield=$.password"`
+		Limit    int    `json:"limit" validate:"ltfield=$.max"`
+	} `json:"account"`
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want []string
+	}{
+		{"valid", map[string]interface{}{
+			"password": "secret", "password_confirmation": "secret", "nickname": "nick",
+			"start_date": "31/12/2023", "end_date": "01/01/2024", "min": 1, "max": 9,
+			"account": map[string]interface{}{"password": "secret", "limit": 5},
+		}, nil},
+		{"different", map[string]interface{}{
+			"password": "secret", "password_confirmation": "other", "nickname": "secret",
+			"start_date": "01/01/2024", "end_date": "31/12/2023", "min": 9, "max": 1,
+			"account": map[string]interface{}{"password": "other", "limit": 5},
+		}, []string{
+			"password_confirmation:eqfield", "nickname:nefield", "end_date:gtfield",
+			"max:gtfield", "account.password:eqfield", "account.limit:ltfield",
+		}},
+		{"missing other field", map[string]interface{}{
+			"password_confirmation": "secret", "end_date": "01/01/2024", "max": 1,
+			"account": map[string]interface{}{"password": "secret", "limit": 0},
+		}, []string{
+			"password:required", "password_confirmation:eqfield", "account.password:eqfield",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateDTO[signupDTO](test.data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+type customerDTO struct {
+	PersonType string `json:"person_type" validate:"required,oneof=person|company"`
+	CNPJ       string `json:"cnpj" validate:"required_if=person_type:company,len=14"`
+	CPF        string `json:"cpf" validate:"required_unless=person_type:company|other,len=11"`
+	Street     string `json:"street"`
+	Number     string `json:"number" validate:"required_with=street"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone" validate:"required_without=email"`
+}
+
+func TestConditionallyRequired(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want []string
+	}{
+		{"company", map[string]interface{}{"person_type": "company", "email": "a@b.co"}, []string{"cnpj:required"}},
+		{"person", map[string]interface{}{"person_type": "person", "email": "a@b.co"}, []string{"cpf:required"}},
+		{"with street", map[string]interface{}{"person_type": "company", "cnpj": "12345678901234", "street": "Main", "phone": "1"}, []string{"number:required"}},
+		{"without email", map[string]interface{}{"person_type": "person", "cpf": "12345678901"}, []string{"phone:required"}},
+		{"other rules still apply", map[string]interface{}{"person_type": "company", "cnpj": "1", "cpf": "1", "phone": "1"}, []string{"cnpj:length", "cpf:length"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateDTO[customerDTO](test.data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestConditionallyRequiredMessages(t *testing.T) {
+	_, err := ValidateDTO[customerDTO](map[string]interface{}{"person_type": "company", "street": "Main"})
+	want := []string{
+		"'cnpj' field is required when 'person_type' is 'company'",
+		"'number' field is required when 'street' is present",
+		"'phone' field is required when 'email' is missing",
+	}
+	if err == nil || !reflect.DeepEqual(err.Messages(), want) {
+		t.Errorf("got %q, want %q", err.Messages(), want)
+	}
+}
@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type decodeProfile struct {
+	Email string `json:"email"`
+}
+
+type decodeDTO struct {
+	Name     string                   `json:"name"`
+	Age      int                      `json:"age"`
+	Email    string                   `json:"email" validate:"email"`
+	Profiles []decodeProfile          `json:"profiles"`
+	Hosts    map[string]decodeProfile `json:"hosts"`
+	Matrix   [][]int                  `json:"matrix"`
+}
+
+func TestValidateJSONTypeMismatches(t *testing.T) {
+	type fieldError struct {
+		Name  string
+		Rule  string
+		Value interface{}
+		Path  string
+	}
+	tests := []struct {
+		name string
+		json string
+		want []fieldError
+	}{
+		{
+			"every mismatch with the validation errors",
+			`{"name":5,"age":"x","email":"bad"}`,
+			[]fieldError{{"email", "email", "bad", "/email"}, {"age", "type", "x", "/age"}, {"name", "type", float64(5), "/name"}},
+		},
+		{"slice element", `{"profiles":[{"email":1}]}`, []fieldError{{"profiles[0].email", "type", float64(1), "/profiles/0/email"}}},
+		{"map key with dots", `{"hosts":{"example.com":{"email":1}}}`, []fieldError{{"hosts[example.com].email", "type", float64(1), "/hosts/example.com/email"}}},
+		{"nested slices", `{"matrix":[[1,"x"]]}`, []fieldError{{"matrix[0][1]", "type", "x", "/matrix/0/1"}}},
+		{"element of the wrong type", `{"profiles":[1,{"email":2}]}`, []fieldError{{"profiles[0]", "type", float64(1), "/profiles/0"}, {"profiles[1].email", "type", float64(2), "/profiles/1/email"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dto, err := ValidateJSON[decodeDTO]([]byte(test.json))
+			if dto != nil {
+				t.Errorf("got %+v, want no DTO", dto)
+			}
+			var got []fieldError
+			if err != nil {
+				for _, e := range err.FieldsErrors() {
+					got = append(got, fieldError{e.Name(), e.RuleType(), e.Value(), e.Path()})
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateDTOPartiallyFillsTheFieldsThatFit(t *testing.T) {
+	data := map[string]interface{}{"name": 5, "age": "x", "email": "a@b.co", "profiles": []interface{}{map[string]interface{}{"email": "c"}}}
+	dto, err := ValidateDTOPartially[decodeDTO](data)
+	if want := []string{"age:type", "name:type"}; !reflect.DeepEqual(errorsOf(err), want) {
+		t.Errorf("got %v, want %v", errorsOf(err), want)
+	}
+	want := decodeDTO{Email: "a@b.co", Profiles: []decodeProfile{{"c"}}}
+	if !reflect.DeepEqual(*dto, want) {
+		t.Errorf("got %+v, want %+v", *dto, want)
+	}
+}
+
+func TestValidateDTOSkipsBuildErrorsOfFailedFields(t *testing.T) {
+	type dto struct {
+		Ids   []int          `json:"ids" validate:"slice:maxlen=1"`
+		Owner *decodeProfile `json:"owner" validate:"required"`
+		Age   int            `json:"age"`
+	}
+	data := map[string]interface{}{"ids": []interface{}{"a", "b"}, "owner": 1, "age": "x"}
+	_, err := ValidateDTO[dto](data)
+	if want := []string{"ids:slice:maxlen", "age:type", "owner:type"}; !reflect.DeepEqual(errorsOf(err), want) {
+		t.Fatalf("got %v, want %v", errorsOf(err), want)
+	}
+	// the elements removed while building are still in the error of the field
+	if got, want := err.FieldsErrors()[0].Value(), []interface{}{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got value %v, want %v", got, want)
+	}
+}
+
+func TestValidateJSONDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"malformed", `{"name":`},
+		{"empty", ``},
+		{"not an object", `[1]`},
+		{"null", `null`},
+		{"null with spaces", ` null `},
+		{"trailing data", `{"name":"a"} {}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateJSON[decodeDTO]([]byte(test.json))
+			if want := []string{":decode"}; !reflect.DeepEqual(errorsOf(err), want) {
+				t.Errorf("got %v, want %v", errorsOf(err), want)
+			}
+		})
+	}
+}
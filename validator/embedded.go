@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// jsonField is a field of a struct as encoding/json sees it. Index is the
+// path from the outer struct, longer than one for the fields promoted from
+// embedded structs.
+type jsonField struct {
+	reflect.StructField
+	name   string
+	tagged bool // named by a tag rather than by its Go name
+}
+
+// jsonFields lists the fields of a struct the way encoding/json does:
+// unexported fields and fields with `json:"-"` are skipped and the fields of
+// embedded structs without a name in their `json` tag are promoted to the
+// parent, while an embedded struct named by its tag is a regular field. When
+// a name repeats, the least nested field wins, and names repeated at the same
+// depth are ambiguous and dropped, unless only one of them is tagged.
+func jsonFields(t reflect.Type) []jsonField {
+	candidates := collectFields(t, nil, map[reflect.Type]bool{})
+	byName := map[string][]jsonField{}
+	for _, candidate := range candidates {
+		byName[candidate.name] = append(byName[candidate.name], candidate)
+	}
+	var fields []jsonField
+	for _, candidate := range candidates {
+		if winner, ok := dominantField(byName[candidate.name]); ok && slices.Equal(winner.Index, candidate.Index) {
+			fields = append(fields, candidate)
+		}
+	}
+	return fields
+}
+
+// collectFields walks the fields of t in order, descending into embedded
+// structs. visiting guards against embedded pointers to an enclosing type.
+func collectFields(t reflect.Type, parentIndex []int, visiting map[reflect.Type]bool) []jsonField {
+	visiting[t] = true
+	defer delete(visiting, t)
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		structField.Index = append(append([]int{}, parentIndex...), i)
+		fieldType := derefType(structField.Type)
+		if structField.Anonymous {
+			if !structField.IsExported() && fieldType.Kind() != reflect.Struct {
+				continue
+			}
+		} else if !structField.IsExported() {
+			continue
+		}
+		jsonName := strings.Split(structField.Tag.Get(jsonTag), ",")[0]
+		if structField.Anonymous && jsonName == "" && fieldType.Kind() == reflect.Struct && fieldType != timeType {
+			if !visiting[fieldType] {
+				fields = append(fields, collectFields(fieldType, structField.Index, visiting)...)
+			}
+			continue
+		}
+		if name := FieldName(structField); name != "" {
+			fields = append(fields, jsonField{structField, name, tagName(structField) != ""})
+		}
+	}
+	return fields
+}
+
+// dominantField picks the field that encoding/json uses among the fields
+// with the same name: the least nested one or, among the least nested, the
+// only one named by a tag. Any other tie can't be told apart.
+func dominantField(fields []jsonField) (jsonField, bool) {
+	dominant, ambiguous := fields[0], false
+	for _, field := range fields[1:] {
+		depth, dominantDepth := len(field.Index), len(dominant.Index)
+		if depth < dominantDepth || depth == dominantDepth && field.tagged && !dominant.tagged {
+			dominant, ambiguous = field, false
+		} else if depth == dominantDepth && field.tagged == dominant.tagged {
+			ambiguous = true
+		}
+	}
+	return dominant, !ambiguous
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type auditDTO struct {
+	CreatedBy string `json:"created_by" validate:"required"`
+	Status    string `json:"status" validate:"required"`
+}
+
+// PaginationDTO is exported because encoding/json can't allocate an embedded
+// pointer to an unexported struct.
+type PaginationDTO struct {
+	Page    int `json:"page" validate:"min=1"`
+	PerPage int `json:"per_page" validate:"between=1:100"`
+}
+
+type hiddenDTO struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type listOrdersDTO struct {
+	auditDTO
+	*PaginationDTO
+	hiddenDTO
+	Named  auditDTO `json:"named"`
+	Status string   `json:"status" validate:"oneof=open|closed"`
+	secret string   `validate:"required"`
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"created_by": "ann", "page": 1, "per_page": 10, "token": "t", "status": "open",
+			"named": map[string]interface{}{"created_by": "bob", "status": "x"},
+		}
+	}
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+		want  []string
+	}{
+		{"valid", "", nil, nil},
+		{"promoted", "created_by", "", []string{"created_by:required"}},
+		{"promoted through a pointer", "per_page", 200, []string{"per_page:between"}},
+		{"promoted from an unexported struct", "token", nil, []string{"token:required"}},
+		{"shadowed by the shallower field", "status", "x", []string{"status:oneof"}},
+		{"named", "named", map[string]interface{}{"status": "x"}, []string{"named.created_by:required"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := valid()
+			if test.field != "" {
+				data[test.field] = test.value
+			}
+			dto, err := ValidateDTO[listOrdersDTO](data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if test.want == nil && (dto.CreatedBy != "ann" || dto.PaginationDTO == nil || dto.Page != 1 || dto.Token != "t") {
+				t.Errorf("got %+v, want the promoted fields decoded", dto)
+			}
+		})
+	}
+}
+
+type codeDTO struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type otherCodeDTO struct {
+	Code string `json:"code" validate:"required"`
+}
+
+func TestEmbeddedAmbiguousFieldsAreIgnored(t *testing.T) {
+	// built at run time, since vet rejects a json tag repeated at the same depth
+	ambiguous := reflect.StructOf([]reflect.StructField{
+		{Name: "CodeDTO", Type: reflect.TypeOf(codeDTO{}), Anonymous: true},
+		{Name: "OtherCodeDTO", Type: reflect.TypeOf(otherCodeDTO{}), Anonymous: true},
+		{Name: "Name", Type: reflect.TypeOf(""), Tag: `json:"name"`},
+	})
+	var names []string
+	for _, field := range jsonFields(ambiguous) {
+		names = append(names, field.name)
+	}
+	if want := []string{"name"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %q, want %q", names, want)
+	}
+}
+
+type untaggedDTO struct {
+	Address auditDTO
+	Note    string `validate:"required"`
+}
+
+func TestUntaggedFieldsAreNamedByTheirGoName(t *testing.T) {
+	dto, err := ValidateDTOPartially[untaggedDTO](map[string]interface{}{"Address": map[string]interface{}{"status": "open"}})
+	if want := []string{"Address.created_by:required", "Note:required"}; !reflect.DeepEqual(errorsOf(err), want) {
+		t.Errorf("got %q, want %q", errorsOf(err), want)
+	}
+	if dto.Address.Status != "open" {
+		t.Errorf("got %+v, want Address.Status decoded", dto)
+	}
+}
+
+func TestEmbeddedTaggedFieldWinsOverUntagged(t *testing.T) {
+	type tagged struct {
+		Code string `json:"Code"`
+	}
+	type untagged struct {
+		Code string
+	}
+	fields := jsonFields(reflect.StructOf([]reflect.StructField{
+		{Name: "Untagged", Type: reflect.TypeOf(untagged{}), Anonymous: true},
+		{Name: "Tagged", Type: reflect.TypeOf(tagged{}), Anonymous: true},
+	}))
+	if len(fields) != 1 || fields[0].Index[0] != 1 {
+		t.Errorf("got %+v, want the tagged field", fields)
+	}
+}
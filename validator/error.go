@@ -6,11 +6,16 @@ import (
 )
 
 type ValidationError interface {
+	error
+	Unwrap() []error
 	String() string
 	Messages() []string
+	MessagesIn(locale string) []string
 	Fields() []string
 	RuleTypes() []string
 	FieldsErrors() []rules.FieldError
+	Problem() Problem
+	ProblemIn(locale string) Problem
 }
 
 type validationError struct {
@@ -21,10 +26,30 @@ func newValidationError(fieldErrors []rules.FieldError) ValidationError {
 	return &validationError{fieldErrors}
 }
 
+// NewValidationError groups field errors produced outside of ValidateDTO,
+// such as decoding errors, into a ValidationError.
+func NewValidationError(fieldErrors ...rules.FieldError) ValidationError {
+	return newValidationError(fieldErrors)
+}
+
 func (v *validationError) String() string {
 	return strings.Join(v.Messages(), " & ")
 }
 
+func (v *validationError) Error() string {
+	return v.String()
+}
+
+// Unwrap exposes every field error so errors.Is and errors.As can match them
+// through any chain wrapping the validation error.
+func (v *validationError) Unwrap() []error {
+	errs := make([]error, 0, len(v.fieldErrors))
+	for _, fieldError := range v.fieldErrors {
+		errs = append(errs, fieldError)
+	}
+	return errs
+}
+
 func (v *validationError) Messages() []string {
 	var messages []string
 	for _, fieldError := range v.fieldErrors {
@@ -33,6 +58,16 @@ func (v *validationError) Messages() []string {
 	return messages
 }
 
+// MessagesIn renders the messages in the given locale (e.g. the result of
+// rules.MatchLocale over an Accept-Language header).
+func (v *validationError) MessagesIn(locale string) []string {
+	var messages []string
+	for _, fieldError := range v.fieldErrors {
+		messages = append(messages, fieldError.MessageIn(locale))
+	}
+	return messages
+}
+
 func (v *validationError) Fields() []string {
 	var fields []string
 	for _, fieldError := range v.fieldErrors {
@@ -52,3 +87,13 @@ func (v *validationError) RuleTypes() []string {
 func (v *validationError) FieldsErrors() []rules.FieldError {
 	return v.fieldErrors
 }
+
+// Problem renders the errors as an RFC 7807 document with the messages in the
+// default locale.
+func (v *validationError) Problem() Problem {
+	return newProblem(v.fieldErrors, rules.DefaultLocale)
+}
+
+func (v *validationError) ProblemIn(locale string) Problem {
+	return newProblem(v.fieldErrors, locale)
+}
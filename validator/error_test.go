@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+// errorsOf lists the errors as "name:rule", in order, or nil for no errors.
+func errorsOf(err ValidationError) []string {
+	if err == nil {
+		return nil
+	}
+	var errs []string
+	for _, fieldError := range err.FieldsErrors() {
+		errs = append(errs, fieldError.Name()+":"+fieldError.RuleType())
+	}
+	return errs
+}
+
+type errorAccount struct {
+	Name  string `json:"name" validate:"required,maxlen=10"`
+	Email string `json:"email" validate:"email"`
+}
+
+func TestValidationError(t *testing.T) {
+	_, err := ValidateDTO[errorAccount](map[string]interface{}{"name": "My Awesome Name", "email": "bad"})
+	if err == nil {
+		t.Fatal("invalid data passed")
+	}
+	if want := []string{"name", "email"}; !reflect.DeepEqual(err.Fields(), want) {
+		t.Errorf("Fields() = %v, want %v", err.Fields(), want)
+	}
+	if want := []string{rules.MAX_LENGTH, rules.EMAIL_VALIDATION}; !reflect.DeepEqual(err.RuleTypes(), want) {
+		t.Errorf("RuleTypes() = %v, want %v", err.RuleTypes(), want)
+	}
+	messages := []string{"'name' field must have 10 characters at max", "the value provided for the 'email' field isn't a valid email"}
+	if !reflect.DeepEqual(err.Messages(), messages) {
+		t.Errorf("Messages() = %q, want %q", err.Messages(), messages)
+	}
+	if want := messages[0] + " & " + messages[1]; err.Error() != want || err.String() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if got := err.MessagesIn("pt-BR")[0]; got != "o campo 'name' deve ter no máximo 10 caracteres" {
+		t.Errorf("MessagesIn(pt-BR) = %q", got)
+	}
+}
+
+func TestValidationErrorWithErrorsAs(t *testing.T) {
+	_, err := ValidateDTO[errorAccount](map[string]interface{}{"email": "bad"})
+	wrapped := fmt.Errorf("creating account: %w", err)
+
+	var validationErr ValidationError
+	if !errors.As(wrapped, &validationErr) || len(validationErr.FieldsErrors()) != 2 {
+		t.Errorf("errors.As didn't find the validation error in %v", wrapped)
+	}
+	var fieldErr rules.FieldError
+	if !errors.As(wrapped, &fieldErr) || fieldErr.Name() != "name" {
+		t.Errorf("errors.As didn't find the first field error in %v", wrapped)
+	}
+	if unwrapped := err.Unwrap(); len(unwrapped) != 2 || !errors.Is(wrapped, unwrapped[1]) {
+		t.Errorf("errors.Is didn't find the field errors in %v", wrapped)
+	}
+}
+
+func TestValidateDTOPartially(t *testing.T) {
+	type account struct {
+		Name      string `json:"name" validate:"required"`
+		BirthDate string `json:"birth_date" validate:"type"`
+		Email     string `json:"email"`
+	}
+	dto, err := ValidateDTOPartially[account](map[string]interface{}{"name": "", "birth_date": "01/01/2000"})
+	if want := []string{"name:required"}; !reflect.DeepEqual(errorsOf(err), want) {
+		t.Errorf("got %v, want %v", errorsOf(err), want)
+	}
+	if want := (account{BirthDate: "01/01/2000"}); dto == nil || *dto != want {
+		t.Errorf("got %+v, want %+v", dto, want)
+	}
+}
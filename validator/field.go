@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/wallrony/go-validator/rules"
 
@@ -15,9 +17,18 @@ const (
 	// Tags
 	validationTag     = "validate"
 	jsonTag           = "json"
+	queryTag          = "query"
+	formTag           = "form"
+	headerTag         = "header"
+	messageTag        = "message"
 	hideParentNameTag = "hideParentName"
+	nullableTag       = "nullable"
 	ifExistsRule      = "ifExists"
 	omitemptyRule     = "omitempty"
+	coerceRule        = "coerce"
+	diveRule          = "dive"
+	keysRule          = "keys"
+	endKeysRule       = "endkeys"
 
 	// TypeNames
 	uuidType     = "uuid"
@@ -34,66 +45,252 @@ var nestedPropsCompiler = regexp.MustCompile(`nestedProps=([a-zA-Z0-9|]+)`)
 
 type Field interface {
 	Name() string
-	Value() interface{}
 	TypeName() string
 	ValidateIfExists() bool
 	HideParentName() bool
 	Omitempty() bool
 	IsStruct() bool
+	IsRecursive() bool
+	IsPointer() bool
+	Nullable() (nullable bool, set bool)
 	IsSlice() bool
+	IsStructSlice() bool
+	IsMap() bool
+	IsStructMap() bool
 	IsRequired() bool
+	IsConditionallyRequired() bool
 	MustValidateType() bool
+	Coerce() bool
+	ReflectType() reflect.Type
 	Hints() []string
+	Rules() []rules.Rule
+	DateFormat() string
 
-	IsValid() ([]rules.FieldError, bool)
+	IsValid(name string, value interface{}, ctx rules.Context) ([]rules.FieldError, bool)
 	GenerateNestedFields() []Field
+	ElementFields() []Field
+	DiveKeyField() Field
+	DiveElementField() Field
 	GenerateRules() []rules.Rule
 	ExtractValueFrom(data map[string]interface{}) interface{}
 
 	SetName(value string)
-	SetValue(value interface{})
 	SetValidateIfExists(value bool)
 }
 
+// field is built once per struct type and then shared by every validation of
+// that type (see planOf), so it must not be changed after buildValidators.
 type field struct {
 	name               string
-	value              interface{}
 	typeName           string
-	rules              []*rules.Rule
+	hints              []string
+	dived              bool
+	rules              []rules.Rule
+	messages           map[string]string
+	dateFormat         string
 	validateIfExists   bool
 	validationTagValue string
 	jsonTagValue       string
 	reflectType        reflect.StructField
 	reflectValue       reflect.Value
+	valueType          reflect.Type
+	nullable           bool
+	nullableSet        bool
+	recursive          bool
+	elementFields      []Field
+	elementFieldsOnce  sync.Once
+	diveKeyField       *field
+	diveElementField   *field
 }
 
 func newField(fieldType reflect.StructField, fieldValue reflect.Value) Field {
-	typeName := fieldType.Type.Kind().String()
-	if fieldType.Type.Kind() == reflect.Slice {
-		typeName = fmt.Sprintf("[]%s", fieldType.Type.Elem().Name())
+	valueType := derefType(fieldType.Type)
+	typeName := valueType.Kind().String()
+	if valueType == timeType {
+		typeName = timeType.String()
+	} else if valueType.Kind() == reflect.Slice || valueType.Kind() == reflect.Array {
+		typeName = fmt.Sprintf("[]%s", derefType(valueType.Elem()).Name())
 	} else if strings.Contains(fieldValue.String(), uuidType) {
 		typeName = uuidTypeName
 	}
-	name := strings.Split(fieldType.Tag.Get(jsonTag), ",")[0]
+	name := FieldName(fieldType)
 	validation := fieldType.Tag.Get(validationTag)
-	validateIfExists := strings.Contains(validation, ifExistsRule) || !strings.Contains(validation, rules.REQUIRED)
-	return &field{
+	hints, diveHints, dived := splitDive(splitHints(validation))
+	validateIfExists := slices.Contains(hints, ifExistsRule) || !slices.Contains(hints, rules.REQUIRED)
+	f := &field{
 		name:               name,
 		typeName:           typeName,
+		hints:              hints,
+		dived:              dived,
 		validationTagValue: validation,
 		validateIfExists:   validateIfExists,
 		jsonTagValue:       fieldType.Tag.Get(jsonTag),
 		reflectType:        fieldType,
 		reflectValue:       fieldValue,
+		valueType:          valueType,
 	}
+	if nullable, err := strconv.ParseBool(fieldType.Tag.Get(nullableTag)); err == nil {
+		f.nullable, f.nullableSet = nullable, true
+	}
+	f.rules = f.GenerateRules()
+	f.messages = customMessagesOf(fieldType, f.hints, f.rules)
+	for _, rule := range f.rules {
+		if rule.Type() == rules.DATE_VALIDATION {
+			f.dateFormat = rule.Argument()
+		}
+	}
+	if dived && f.IsMap() {
+		keyHints, elementHints := splitDiveKeys(diveHints)
+		if keyHints != nil {
+			f.diveKeyField = f.newDiveField(valueType.Key(), keyHints)
+		}
+		f.diveElementField = f.newDiveField(valueType.Elem(), elementHints)
+	} else if dived && f.IsSlice() {
+		f.diveElementField = f.newDiveField(valueType.Elem(), diveHints)
+	} else if dived {
+		// the hints after `dive` would never be checked, so, like an invalid
+		// pattern, this is a programming error
+		panic(fmt.Sprintf("validator: dive on field '%s' of type %s, which isn't a slice, array or map", fieldType.Name, fieldType.Type))
+	}
+	return f
 }
 
-func (f *field) Name() string {
-	return f.name
+// newDiveField creates the field that validates each key or element of the
+// field with the hints after `dive`. It has no name, so its errors are named
+// after the element ("metadata[region]"), and it uses the `message` tags of
+// the field. The hints may have another `dive`, for nested containers.
+func (f *field) newDiveField(t reflect.Type, hints []string) *field {
+	tag := reflect.StructTag(validationTag + ":" + strconv.Quote(joinHints(hints)))
+	element := newField(reflect.StructField{Name: f.reflectType.Name, Type: t, Tag: tag}, reflect.Zero(t)).(*field)
+	element.name = "" // elements are held by an unnamed key (see tryDiveElement)
+	element.messages = customMessagesOf(f.reflectType, element.hints, element.rules)
+	return element
+}
+
+// splitDive separates the hints of the container, before `dive`, from the
+// hints of its elements, after it.
+func splitDive(hints []string) (containerHints, elementHints []string, dived bool) {
+	index := slices.Index(hints, diveRule)
+	if index < 0 {
+		return hints, nil, false
+	}
+	return hints[:index], hints[index+1:], true
+}
+
+// splitDiveKeys separates the hints of the keys of a map, between `keys` and
+// `endkeys` right after `dive`, from the hints of its values.
+func splitDiveKeys(hints []string) (keyHints, elementHints []string) {
+	if len(hints) == 0 || hints[0] != keysRule {
+		return nil, hints
+	}
+	end := slices.Index(hints, endKeysRule)
+	if end < 0 {
+		return hints[1:], nil
+	}
+	return hints[1:end], hints[end+1:]
+}
+
+// joinHints is the inverse of splitHints.
+func joinHints(hints []string) string {
+	escaped := make([]string, len(hints))
+	for i, hint := range hints {
+		escaped[i] = strings.ReplaceAll(hint, ",", "\\,")
+	}
+	return strings.Join(escaped, ",")
+}
+
+// splitHints splits the `validate` tag by its commas. A comma that is part of
+// a rule argument (e.g. in a pattern) is escaped as "\,"; any other escape is
+// kept as is, so "\d" still reaches the pattern rule.
+func splitHints(validation string) []string {
+	var hints []string
+	var hint strings.Builder
+	for i := 0; i < len(validation); i++ {
+		switch {
+		case validation[i] == '\\' && i+1 < len(validation) && validation[i+1] == ',':
+			hint.WriteByte(',')
+			i++
+		case validation[i] == '\\' && i+1 < len(validation):
+			hint.WriteString(validation[i : i+2])
+			i++
+		case validation[i] == ',':
+			hints = append(hints, hint.String())
+			hint.Reset()
+		default:
+			hint.WriteByte(validation[i])
+		}
+	}
+	return append(hints, hint.String())
+}
+
+// customMessagesOf reads the `message` tag, used by every rule of the field,
+// and the `message.<rule>` tags, used by a single rule. A rule is named either
+// by its hint ("message.minlen") or by its type ("message.minlength"), and
+// colons are written as dots since tag keys can't have them
+// ("message.slice.minlen"). The generic message is stored under the empty key
+// and the others under the type of their rule.
+func customMessagesOf(fieldType reflect.StructField, hints []string, fieldRules []rules.Rule) map[string]string {
+	messages := map[string]string{}
+	if message, ok := fieldType.Tag.Lookup(messageTag); ok {
+		messages[""] = message
+	}
+	for _, rule := range fieldRules {
+		if message, ok := fieldType.Tag.Lookup(messageKey(rule.Type())); ok {
+			messages[rule.Type()] = message
+		}
+	}
+	for _, hint := range hints {
+		rule := rules.GetRuleByHint(hint)
+		if rule == nil {
+			continue
+		}
+		if message, ok := fieldType.Tag.Lookup(messageKey(strings.SplitN(hint, "=", 2)[0])); ok {
+			messages[rule.Type()] = message
+		}
+	}
+	return messages
+}
+
+// messageKey is the tag of the message of a rule named name.
+func messageKey(name string) string {
+	return messageTag + fieldDelimiter + strings.ReplaceAll(name, ":", fieldDelimiter)
+}
+
+// derefType is the type a pointer type points to, or t itself.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
 }
 
-func (f *field) Value() interface{} {
-	return f.value
+// FieldName is the name under which a struct field is read from the data: the
+// name in its `json` tag or, for fields bound from requests without one (or
+// with `json:"-"`), the name in its `query`, `form` or `header` tag. Untagged
+// fields are named by their Go name, as in encoding/json, while a field with
+// just `json:"-"` has no name.
+func FieldName(fieldType reflect.StructField) string {
+	if name := tagName(fieldType); name != "" {
+		return name
+	} else if fieldType.Tag.Get(jsonTag) == "-" {
+		return ""
+	}
+	return fieldType.Name
+}
+
+// tagName is the name in the first of the field's `json`, `query`, `form` and
+// `header` tags that has one.
+func tagName(fieldType reflect.StructField) string {
+	for _, tag := range []string{jsonTag, queryTag, formTag, headerTag} {
+		if name := strings.Split(fieldType.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+func (f *field) Name() string {
+	return f.name
 }
 
 func (f *field) TypeName() string {
@@ -108,52 +305,95 @@ func (f *field) SetName(value string) {
 	f.name = value
 }
 
-func (f *field) SetValue(value interface{}) {
-	f.value = value
-}
-
 func (f *field) SetValidateIfExists(value bool) {
 	f.validateIfExists = value
 }
 
-func (f *field) IsValid() ([]rules.FieldError, bool) {
+func (f *field) IsValid(name string, value interface{}, ctx rules.Context) ([]rules.FieldError, bool) {
 	var errs []rules.FieldError
-	for _, rule := range f.GenerateRules() {
-		if f.IsSlice() && !rule.IsSliceRule() {
-			if f.value == nil || reflect.ValueOf(f.value).Len() == 0 {
-				if rule.Type() == rules.REQUIRED {
-					errs = append(errs, rule.GenerateError(f.Name()))
+	for _, rule := range f.rules {
+		if value == nil && f.IsConditionallyRequired() && !validatesMissing(rule) {
+			continue // only the conditions decide whether a missing value is an error
+		}
+		if f.IsSlice() && !rule.IsSliceRule() && !f.dived {
+			if value == nil || reflect.ValueOf(value).Len() == 0 {
+				if validatesMissing(rule) && !rule.IsValidWithContext(value, ctx) {
+					errs = append(errs, f.generateError(rule, name, value))
 				}
 				continue
 			}
-			for i := 0; i < reflect.ValueOf(f.value).Len(); i++ {
-				element := reflect.ValueOf(f.value).Index(i).Interface()
-				if !rule.IsValid(element) {
-					errs = append(errs, rule.GenerateError(fmt.Sprintf("%s[%d]", f.Name(), i)))
+			for i := 0; i < reflect.ValueOf(value).Len(); i++ {
+				element := reflect.ValueOf(value).Index(i).Interface()
+				if !rule.IsValidWithContext(element, ctx) {
+					errs = append(errs, f.generateError(rule, fmt.Sprintf("%s[%d]", name, i), element))
 				}
 			}
-		} else if !rule.IsValid(f.value) {
-			errs = append(errs, rule.GenerateError(f.Name()))
+		} else if !rule.IsValidWithContext(value, ctx) {
+			if multiErrorRule, ok := rule.(rules.MultiErrorRule); ok {
+				for _, fieldError := range multiErrorRule.GenerateErrors(name, value) {
+					errs = append(errs, f.customizeError(rule, fieldError, fieldError.Value()))
+				}
+			} else {
+				errs = append(errs, f.generateError(rule, name, value))
+			}
 			break
 		}
 	}
 	return errs, len(errs) == 0
 }
 
+// generateError creates the error of a rule (see customizeError).
+func (f *field) generateError(rule rules.Rule, name string, value interface{}) rules.FieldError {
+	return f.customizeError(rule, rules.WithValue(rule.GenerateError(name), value), value)
+}
+
+// customizeError replaces the message of an error of the rule when the field
+// has a `message` tag for it.
+func (f *field) customizeError(rule rules.Rule, fieldError rules.FieldError, value interface{}) rules.FieldError {
+	if template, ok := f.messages[rule.Type()]; ok {
+		return rules.WithMessage(fieldError, template, rule.Argument(), value)
+	} else if template, ok := f.messages[""]; ok {
+		return rules.WithMessage(fieldError, template, rule.Argument(), value)
+	}
+	return fieldError
+}
+
 func (f *field) GenerateNestedFields() []Field {
-	var nestedFieldInterfaceValue interface{}
-	if f.IsStruct() {
-		nestedFieldInterfaceValue = f.reflectValue.Interface()
-	} else if f.IsSlice() {
-		nestedFieldInterfaceValue = reflect.Zero(f.reflectValue.Type().Elem()).Interface()
-	} else {
-		return []Field{} // unknown type
-	}
-	var nestedFields []Field
-	var nestedFieldInterfaceType = reflect.TypeOf(nestedFieldInterfaceValue).Kind()
-	if nestedFieldInterfaceType == reflect.Struct {
-		nestedFields = buildValidators(nestedFieldInterfaceValue)
+	return f.nestedFields(map[reflect.Type]bool{})
+}
+
+// nestedFields is GenerateNestedFields within buildFields, which tells the
+// structs being flattened.
+func (f *field) nestedFields(visiting map[reflect.Type]bool) []Field {
+	if !f.IsStruct() {
+		return []Field{} // slices of structs are expanded per element by ElementFields
 	}
+	parentName := f.name
+	if f.HideParentName() {
+		parentName = ""
+	}
+	return f.filterNestedFields(buildFields(f.valueType, visiting), parentName)
+}
+
+// ElementFields returns the fields of the element struct of a slice or map,
+// or of the struct of a recursive field, named relative to each element. They
+// are built on first use so that recursive types such as `Children []Node`
+// don't recurse forever.
+func (f *field) ElementFields() []Field {
+	if !f.IsStructSlice() && !f.IsStructMap() && !f.recursive {
+		return []Field{}
+	}
+	f.elementFieldsOnce.Do(func() {
+		elementType := f.valueType
+		if !f.recursive {
+			elementType = derefType(f.valueType.Elem())
+		}
+		f.elementFields = f.filterNestedFields(buildValidators(reflect.Zero(elementType).Interface()), "")
+	})
+	return f.elementFields
+}
+
+func (f *field) filterNestedFields(nestedFields []Field, parentName string) []Field {
 	var validNestedFieldNames []string
 	if strings.Contains(f.validationTagValue, nestedPropsTag) {
 		if ok := nestedPropsCompiler.Match([]byte(f.validationTagValue)); ok {
@@ -174,11 +414,11 @@ func (f *field) GenerateNestedFields() []Field {
 		}
 		nestedFields = filteredFields
 	}
+	if parentName == "" {
+		return nestedFields
+	}
 	for _, nestedField := range nestedFields {
-		if f.HideParentName() {
-			continue
-		}
-		nestedField.SetName(strings.ToLower(f.name) + fieldDelimiter + nestedField.Name())
+		nestedField.SetName(parentName + fieldDelimiter + nestedField.Name())
 	}
 	return nestedFields
 }
@@ -188,10 +428,10 @@ func (f *field) GenerateRules() []rules.Rule {
 	if f.IsRequired() {
 		validators = append(validators, rules.NewRequiredRule(f.typeName))
 	}
-	if f.IsRequired() || f.MustValidateType() {
+	if (f.IsRequired() || f.MustValidateType()) && !(f.IsSlice() && f.dived) {
 		typeName := f.typeName
 		if f.IsSlice() {
-			typeName = f.reflectType.Type.Elem().Name()
+			typeName = derefType(f.valueType.Elem()).Name()
 		}
 		if validator := rules.GetTypeValidator(typeName); validator != nil {
 			validators = append(validators, validator)
@@ -208,22 +448,7 @@ func (f *field) GenerateRules() []rules.Rule {
 }
 
 func (f *field) ExtractValueFrom(data map[string]interface{}) interface{} {
-	if !strings.Contains(f.name, fieldDelimiter) {
-		if reflect.TypeOf(data).Kind() == reflect.Slice {
-			return data
-		}
-		return data[f.name]
-	}
-	var value interface{} = data
-	for _, key := range strings.Split(f.name, fieldDelimiter) {
-		if v, ok := value.(map[string]interface{}); ok {
-			value = v[key]
-		}
-		if value == nil {
-			return nil
-		}
-	}
-	return value
+	return extractPath(data, f.name)
 }
 
 func (f *field) HideParentName() bool {
@@ -234,22 +459,128 @@ func (f *field) Omitempty() bool {
 	return strings.Contains(f.jsonTagValue, omitemptyRule)
 }
 
+// IsStruct tells if the field is a nested struct. time.Time, although a
+// struct, is validated as a single value.
 func (f *field) IsStruct() bool {
-	return f.reflectType.Type.Kind() == reflect.Struct
+	return f.valueType.Kind() == reflect.Struct && f.valueType != timeType
+}
+
+// IsRecursive tells if the field points to a struct that contains it, as in
+// `Next *Node`. Its fields aren't flattened with the others but validated for
+// each value (see ElementFields), so hideParentName doesn't apply to it.
+func (f *field) IsRecursive() bool {
+	return f.recursive
+}
+
+// IsPointer tells if the field is a pointer, whose nil value is sent as null.
+func (f *field) IsPointer() bool {
+	return f.reflectType.Type.Kind() == reflect.Ptr
 }
 
+// Nullable reads the `nullable` tag: "true" accepts an explicit null (even in
+// a required field, which then only requires the key), "false" rejects it
+// (even in an optional field). set is false when the tag is missing, in which
+// case a null is treated as a missing value.
+func (f *field) Nullable() (nullable bool, set bool) {
+	return f.nullable, f.nullableSet
+}
+
+func (f *field) IsMap() bool {
+	return f.valueType.Kind() == reflect.Map
+}
+
+// IsStructMap tells if the field is a map whose values are structs, whose
+// fields are validated for every value (see ElementFields).
+func (f *field) IsStructMap() bool {
+	if !f.IsMap() {
+		return false
+	}
+	element := derefType(f.valueType.Elem())
+	return element.Kind() == reflect.Struct && element != timeType
+}
+
+// DiveKeyField validates each key of a map with the hints between `keys` and
+// `endkeys`. It is nil when there are no such hints.
+func (f *field) DiveKeyField() Field {
+	if f.diveKeyField == nil {
+		return nil
+	}
+	return f.diveKeyField
+}
+
+// DiveElementField validates each element with the hints after `dive`. It is
+// nil when the field has no `dive`.
+func (f *field) DiveElementField() Field {
+	if f.diveElementField == nil {
+		return nil
+	}
+	return f.diveElementField
+}
+
+// IsSlice tells if the field is a slice or an array. Unless the field has a
+// `dive`, its rules apply to each element (see IsValid).
 func (f *field) IsSlice() bool {
-	return f.reflectType.Type.Kind() == reflect.Slice
+	return f.valueType.Kind() == reflect.Slice || f.valueType.Kind() == reflect.Array
+}
+
+func (f *field) IsStructSlice() bool {
+	if !f.IsSlice() {
+		return false
+	}
+	element := derefType(f.valueType.Elem())
+	return element.Kind() == reflect.Struct && element != timeType
 }
 
 func (f *field) IsRequired() bool {
-	return strings.Contains(f.validationTagValue, rules.REQUIRED) && !f.Omitempty()
+	return slices.Contains(f.hints, rules.REQUIRED) && !f.Omitempty()
+}
+
+// IsConditionallyRequired tells if the field has rules such as required_if,
+// or custom rules registered with rules.RegisterWithContext, which must run
+// even when the value is missing.
+func (f *field) IsConditionallyRequired() bool {
+	if f.IsRequired() {
+		return false
+	}
+	for _, rule := range f.rules {
+		if validatesMissing(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// validatesMissing tells if the rule runs when the value is missing or null
+// (see rules.MissingValueRule).
+func validatesMissing(rule rules.Rule) bool {
+	missingValueRule, ok := rule.(rules.MissingValueRule)
+	return ok && missingValueRule.ValidatesMissingValue()
 }
 
 func (f *field) MustValidateType() bool {
-	return strings.Contains(f.validationTagValue, rules.TYPE)
+	return slices.Contains(f.hints, rules.TYPE)
+}
+
+// Coerce tells if strings received for the field must be converted to its Go
+// type before the validation (the `coerce` hint).
+func (f *field) Coerce() bool {
+	return slices.Contains(f.hints, coerceRule)
+}
+
+func (f *field) ReflectType() reflect.Type {
+	return f.reflectType.Type
 }
 
 func (f *field) Hints() []string {
-	return strings.Split(f.validationTagValue, ",")
+	return f.hints
+}
+
+func (f *field) Rules() []rules.Rule {
+	return f.rules
+}
+
+// DateFormat is the format of the field's `date` rule, used to compare it
+// with other fields. It is empty when the field has no date rule.
+func (f *field) DateFormat() string {
+	return f.dateFormat
 }
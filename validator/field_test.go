@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitHints(t *testing.T) {
+	tests := []struct {
+		validation string
+		want       []string
+	}{
+		{"required", []string{"required"}},
+		{"required,minlen=3", []string{"required", "minlen=3"}},
+		{`pattern=^\d{1\,3}$,required`, []string{`pattern=^\d{1,3}$`, "required"}},
+		{`pattern=^\w+$`, []string{`pattern=^\w+$`}},
+		{"", []string{""}},
+	}
+	for _, test := range tests {
+		t.Run(test.validation, func(t *testing.T) {
+			got := splitHints(test.validation)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if joined := joinHints(got); !reflect.DeepEqual(splitHints(joined), got) {
+				t.Errorf("joinHints(%q) = %q isn't split back", got, joined)
+			}
+		})
+	}
+}
+
+func TestSplitDive(t *testing.T) {
+	tests := []struct {
+		hints                     []string
+		container, keys, elements []string
+	}{
+		{[]string{"required"}, []string{"required"}, nil, nil},
+		{[]string{"slice:minlen=1", "dive", "email"}, []string{"slice:minlen=1"}, nil, []string{"email"}},
+		{[]string{"dive", "keys", "maxlen=3", "endkeys", "min=1"}, []string{}, []string{"maxlen=3"}, []string{"min=1"}},
+		{[]string{"dive", "dive", "min=1"}, []string{}, nil, []string{"dive", "min=1"}},
+	}
+	for _, test := range tests {
+		container, elements, _ := splitDive(test.hints)
+		keys, elements := splitDiveKeys(elements)
+		if !reflect.DeepEqual(container, test.container) || !reflect.DeepEqual(keys, test.keys) || !reflect.DeepEqual(elements, test.elements) {
+			t.Errorf("splitDive(%q) = %q %q %q", test.hints, container, keys, elements)
+		}
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	type dto struct {
+		JSON    string `json:"json,omitempty" query:"q"`
+		Query   string `json:"-" query:"q,omitempty"`
+		Form    string `form:"f"`
+		Header  string `header:"X-H"`
+		Untaged string
+		Omitted string `json:",omitempty"`
+		Ignored string `json:"-"`
+	}
+	want := []string{"json", "q", "f", "X-H", "Untaged", "Omitted", ""}
+	for i, name := range want {
+		if got := FieldName(reflect.TypeOf(dto{}).Field(i)); got != name {
+			t.Errorf("FieldName(%s) = %q, want %q", reflect.TypeOf(dto{}).Field(i).Name, got, name)
+		}
+	}
+}
+
+type messagesDTO struct {
+	Name  string   `json:"name" validate:"required,minlen=3" message:"Informe um nome com pelo menos {argument} letras"`
+	Email string   `json:"email" validate:"required,email" message.email:"{value} não é um e-mail válido"`
+	Tags  []string `json:"tags" validate:"slice:minlen=1" message.slice.minlen:"Informe ao menos {argument} tag"`
+}
+
+type hintMessagesDTO struct {
+	Name string    `json:"name" validate:"minlen=3" message.minlen:"Nome curto"`
+	Code string    `json:"code" validate:"len=4" message.len:"Código inválido"`
+	Plan string    `json:"plan" validate:"oneofci=free|pro" message.oneofci:"Plano inválido"`
+	At   string    `json:"at" validate:"datetime" message.datetime:"Data inválida"`
+	Tags []string  `json:"tags" validate:"dive,maxlen=2" message.maxlen:"Tag longa"`
+	Due  time.Time `json:"due" validate:"required_if=plan:pro" message.required_if:"Informe o vencimento"`
+}
+
+func TestCustomMessages(t *testing.T) {
+	_, err := ValidateDTO[messagesDTO](map[string]interface{}{"name": "ab", "email": "bad", "tags": []interface{}{}})
+	want := []string{"Informe um nome com pelo menos 3 letras", "bad não é um e-mail válido", "Informe ao menos 1 tag"}
+	if err == nil || !reflect.DeepEqual(err.Messages(), want) || !reflect.DeepEqual(err.MessagesIn("pt-BR"), want) {
+		t.Fatalf("got %q, want %q in every locale", err.Messages(), want)
+	}
+	_, err = ValidateDTO[messagesDTO](map[string]interface{}{"name": "abc", "tags": []interface{}{"a"}})
+	if want := []string{"'email' field of type 'string' is missing or empty"}; !reflect.DeepEqual(err.Messages(), want) {
+		t.Errorf("got %q, want the generated message %q for a rule without a custom one", err.Messages(), want)
+	}
+}
+
+func TestCustomMessagesByHint(t *testing.T) {
+	data := map[string]interface{}{"name": "ab", "code": "1", "plan": "gold", "at": "x", "tags": []interface{}{"abc"}}
+	_, err := ValidateDTO[hintMessagesDTO](data)
+	want := []string{"Nome curto", "Código inválido", "Plano inválido", "Data inválida", "Tag longa"}
+	if err == nil || !reflect.DeepEqual(err.Messages(), want) {
+		t.Errorf("got %q, want %q", err.Messages(), want)
+	}
+	_, err = ValidateDTO[hintMessagesDTO](map[string]interface{}{"plan": "pro"})
+	if want := []string{"Informe o vencimento"}; err == nil || !reflect.DeepEqual(err.Messages(), want) {
+		t.Errorf("got %q, want %q", err.Messages(), want)
+	}
+}
@@ -0,0 +1,160 @@
+// Package httpbind decodes and validates requests into DTOs for net/http
+// handlers, reading the JSON body and the fields tagged with `query`, `form`
+// and `header`.
+package httpbind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/wallrony/go-validator/rules"
+	"github.com/wallrony/go-validator/validator"
+
+	"golang.org/x/exp/slices"
+)
+
+// MaxBodySize is the largest request body, in bytes, that Bind reads.
+var MaxBodySize int64 = 1 << 20
+
+type contextKey[T interface{}] struct{}
+
+// Bind reads the JSON body of the request, along with the fields tagged with
+// `query`, `form` and `header`, and validates it as a T. Bodies that can't be
+// decoded produce a ValidationError with a rules.DECODE error.
+func Bind[T interface{}](r *http.Request) (*T, validator.ValidationError) {
+	data, err := decodeBody(r)
+	if err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	dropBodyKeys(t, data)
+	bound, bindErrs := bindValues(r, t, data)
+	dto, err := validator.ValidateDTO[T](data)
+	if len(bindErrs) > 0 {
+		return nil, validator.NewValidationError(append(bindErrs, withoutFields(err, bindErrs)...)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bound {
+		if field, ok := fieldByIndex(reflect.ValueOf(dto).Elem(), b.index); ok {
+			field.Set(b.value)
+		}
+	}
+	return dto, nil
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex allocating the nil embedded
+// pointers along the way, so the values bound to their fields aren't lost.
+// Like encoding/json, it can't allocate a pointer to an unexported struct.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return v, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// withoutFields drops the validation errors of the fields that already failed
+// to be converted, which would only repeat them.
+func withoutFields(err validator.ValidationError, failed []rules.FieldError) []rules.FieldError {
+	if err == nil {
+		return nil
+	}
+	var errs []rules.FieldError
+	for _, fieldError := range err.FieldsErrors() {
+		name, _, _ := strings.Cut(fieldError.Name(), "[")
+		if !slices.ContainsFunc(failed, func(f rules.FieldError) bool { return f.Name() == name }) {
+			errs = append(errs, fieldError)
+		}
+	}
+	return errs
+}
+
+// Middleware binds the request body as a T and stores it in the request
+// context, retrieved with FromContext. On failure it responds with the errors
+// and next isn't called.
+func Middleware[T interface{}](next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dto, err := Bind[T](r)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, dto)))
+	})
+}
+
+// Handle adapts a function receiving the bound DTO into an http.Handler.
+func Handle[T interface{}](handler func(w http.ResponseWriter, r *http.Request, dto *T)) http.Handler {
+	return Middleware[T](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dto, _ := FromContext[T](r.Context())
+		handler(w, r, dto)
+	}))
+}
+
+// FromContext returns the DTO stored by Middleware.
+func FromContext[T interface{}](ctx context.Context) (*T, bool) {
+	dto, ok := ctx.Value(contextKey[T]{}).(*T)
+	return dto, ok
+}
+
+// WriteError responds with the errors as an RFC 7807 document in the locale
+// of the Accept-Language header: 400 when the body couldn't be decoded and
+// 422 when it didn't pass the validation.
+func WriteError(w http.ResponseWriter, r *http.Request, err validator.ValidationError) error {
+	problem := err.ProblemIn(rules.MatchLocale(r.Header.Get("Accept-Language")))
+	for _, fieldError := range err.FieldsErrors() {
+		if fieldError.RuleType() == rules.DECODE {
+			problem.Status = http.StatusBadRequest
+			problem.Title = http.StatusText(http.StatusBadRequest)
+		}
+	}
+	return problem.Write(w)
+}
+
+// decodeBody decodes the JSON body of the request. Form bodies are parsed into
+// r.PostForm instead, to be read through the `form` tags.
+func decodeBody(r *http.Request) (map[string]interface{}, validator.ValidationError) {
+	data := map[string]interface{}{}
+	if r.Body == nil {
+		return data, nil
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, MaxBodySize)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(MaxBodySize); err != nil {
+			return nil, validator.NewValidationError(rules.NewDecodeError("", err.Error()))
+		}
+		return data, nil
+	} else if mediaType == "application/x-www-form-urlencoded" {
+		if err := r.ParseForm(); err != nil {
+			return nil, validator.NewValidationError(rules.NewDecodeError("", err.Error()))
+		}
+		return data, nil
+	}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&data); err != nil && !errors.Is(err, io.EOF) {
+		return nil, validator.NewValidationError(rules.NewDecodeError("", err.Error()))
+	}
+	if data == nil {
+		return nil, validator.NewValidationError(rules.NewDecodeError("", "expected a JSON object, got null"))
+	}
+	if decoder.More() {
+		return nil, validator.NewValidationError(rules.NewDecodeError("", "unexpected data after the JSON object"))
+	}
+	return data, nil
+}
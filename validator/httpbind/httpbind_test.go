@@ -0,0 +1,120 @@
+package httpbind
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+type Pagination struct {
+	Page int `json:"-" query:"page" validate:"min=1"`
+	Size int `json:"-" query:"size"`
+}
+
+type listDTO struct {
+	*Pagination
+	Query string `json:"-" query:"q"`
+}
+
+func TestBindAllocatesEmbeddedPointers(t *testing.T) {
+	request := httptest.NewRequest("GET", "/?page=3&size=10&q=go", nil)
+	dto, err := Bind[listDTO](request)
+	if err != nil {
+		t.Fatalf("unexpected errors: %v", err)
+	}
+	if dto.Pagination == nil || dto.Page != 3 || dto.Size != 10 || dto.Query != "go" {
+		t.Errorf("got %+v with pagination %+v, want page 3, size 10 and query go", dto, dto.Pagination)
+	}
+}
+
+func TestBindLeavesUnboundEmbeddedPointersNil(t *testing.T) {
+	dto, err := Bind[listDTO](httptest.NewRequest("GET", "/?q=go", nil))
+	if err != nil {
+		t.Fatalf("unexpected errors: %v", err)
+	}
+	if dto.Pagination != nil {
+		t.Errorf("got pagination %+v, want nil", dto.Pagination)
+	}
+}
+
+type accountDTO struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestBindDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed", `{"name":`},
+		{"null", `null`},
+		{"not an object", `"name"`},
+		{"trailing data", `{"name":"a"} {}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/", strings.NewReader(test.body))
+			request.Header.Set("Content-Type", "application/json")
+			_, err := Bind[accountDTO](request)
+			if err == nil || len(err.FieldsErrors()) != 1 || err.RuleTypes()[0] != rules.DECODE {
+				t.Errorf("got %v, want a single decode error", err)
+			}
+		})
+	}
+}
+
+type searchDTO struct {
+	Page   int       `json:"-" query:"page" validate:"min=1"`
+	Tags   []string  `json:"-" query:"tags"`
+	Ids    []int     `json:"-" query:"id"`
+	Exact  *bool     `json:"-" query:"exact"`
+	Since  time.Time `json:"-" query:"since"`
+	Tenant string    `json:"-" header:"X-Tenant" validate:"required"`
+}
+
+func TestBindCoercesRequestValues(t *testing.T) {
+	request := httptest.NewRequest("GET", "/?page=2&tags=a,%20b&id=1&id=2&exact=true&since=2000-01-31T00:00:00Z", nil)
+	request.Header.Set("X-Tenant", "acme")
+	dto, err := Bind[searchDTO](request)
+	if err != nil {
+		t.Fatalf("unexpected errors: %v", err)
+	}
+	exact := true
+	want := searchDTO{2, []string{"a", "b"}, []int{1, 2}, &exact, time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC), "acme"}
+	if !reflect.DeepEqual(*dto, want) {
+		t.Errorf("got %+v, want %+v", *dto, want)
+	}
+}
+
+func TestBindTypeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"not a number", "page=x", []string{"page:type:int"}},
+		{"slice element", "page=1&id=1,x", []string{"id:type:[]int"}},
+		{"not a date", "page=1&since=yesterday", []string{"since:type:time.Time"}},
+		{"rules after conversion", "page=0", []string{"page:min:1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/?"+test.query, nil)
+			request.Header.Set("X-Tenant", "acme")
+			_, err := Bind[searchDTO](request)
+			var got []string
+			if err != nil {
+				for _, fieldError := range err.FieldsErrors() {
+					got = append(got, fieldError.Name()+":"+fieldError.RuleType()+":"+fieldError.Argument())
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
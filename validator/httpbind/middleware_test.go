@@ -0,0 +1,63 @@
+package httpbind
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type signupDTO struct {
+	Name  string `json:"name" validate:"required"`
+	Plan  string `json:"-" query:"plan" form:"plan" validate:"oneof=free|pro"`
+	Token string `json:"-" header:"X-Token"`
+}
+
+func TestHandle(t *testing.T) {
+	handler := Handle(func(w http.ResponseWriter, r *http.Request, dto *signupDTO) {
+		if fromContext, ok := FromContext[signupDTO](r.Context()); !ok || fromContext != dto {
+			t.Errorf("FromContext returned %v, %v, want the bound DTO", fromContext, ok)
+		}
+		fmt.Fprintf(w, "%s %s %s", dto.Name, dto.Plan, dto.Token)
+	})
+	tests := []struct {
+		name           string
+		body           string
+		acceptLanguage string
+		wantStatus     int
+		want           string
+	}{
+		{"valid", `{"name":"ann"}`, "", http.StatusOK, "ann free t"},
+		{"invalid", `{}`, "", http.StatusUnprocessableEntity, "'name' field of type 'string' is missing or empty"},
+		{"invalid in the requested locale", `{}`, "pt-BR,pt;q=0.9", http.StatusUnprocessableEntity, "o campo 'name' do tipo 'string' não foi informado ou está vazio"},
+		{"not decoded", `{`, "", http.StatusBadRequest, "unexpected EOF"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/?plan=free", strings.NewReader(test.body))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("X-Token", "t")
+			request.Header.Set("Accept-Language", test.acceptLanguage)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			if recorder.Code != test.wantStatus || !strings.Contains(recorder.Body.String(), test.want) {
+				t.Errorf("got %d %s, want %d containing %q", recorder.Code, recorder.Body, test.wantStatus, test.want)
+			}
+			if test.wantStatus != http.StatusOK && recorder.Header().Get("Content-Type") != "application/problem+json" {
+				t.Errorf("got content type %q, want application/problem+json", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestMiddlewareStopsOnErrors(t *testing.T) {
+	called := false
+	handler := Middleware[signupDTO](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(`{}`)))
+	if called {
+		t.Error("next was called for an invalid request")
+	}
+}
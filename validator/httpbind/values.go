@@ -0,0 +1,154 @@
+package httpbind
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/wallrony/go-validator/rules"
+	"github.com/wallrony/go-validator/validator"
+)
+
+const jsonTag = "json"
+
+// Tags of the fields read from the request instead of the JSON body.
+const (
+	queryTag  = "query"
+	formTag   = "form"
+	headerTag = "header"
+)
+
+// boundValue is a DTO field read from the query string, the form or the
+// headers, already converted to the type of the field.
+type boundValue struct {
+	index []int
+	value reflect.Value
+}
+
+// bindValues reads the fields of t (including the ones promoted from embedded
+// structs) tagged with `query`, `form` or `header` into data, converting the
+// strings to the type of each field so the `validate` rules see numbers,
+// booleans and slices. Values that can't be converted are kept as received
+// and reported as rules.TYPE errors.
+func bindValues(r *http.Request, t reflect.Type, data map[string]interface{}) ([]boundValue, []rules.FieldError) {
+	var bound []boundValue
+	var errs []rules.FieldError
+	for _, fieldType := range reflect.VisibleFields(t) {
+		if !fieldType.IsExported() || fieldType.Anonymous {
+			continue // the fields of embedded structs are visited on their own
+		}
+		values := requestValues(r, fieldType)
+		if len(values) == 0 {
+			continue
+		}
+		name := validator.FieldName(fieldType)
+		value, err := coerce(values, fieldType.Type)
+		if err != nil {
+			var received interface{} = values[0]
+			if fieldType.Type.Kind() == reflect.Slice {
+				received = values
+			}
+			data[name] = received
+			errs = append(errs, validator.NewTypeError(name, fieldType.Type, received))
+			continue
+		}
+		data[name] = value.Interface()
+		bound = append(bound, boundValue{fieldType.Index, value})
+	}
+	return bound, errs
+}
+
+// dropBodyKeys removes from data the keys of the JSON body that encoding/json
+// would decode into the fields read only from the query string, the form or
+// the headers. Those fields have no name in their `json` tag, so encoding/json
+// matches their Go name ignoring case, while the rules see them under the name
+// of their other tag: a body key such as "term" would skip the rules of `Term`.
+// Keys that are the exact `json` name of another field are kept, since
+// encoding/json decodes them into that field instead.
+func dropBodyKeys(t reflect.Type, data map[string]interface{}) {
+	jsonNames := map[string]bool{}
+	var goNames []string
+	for _, fieldType := range reflect.VisibleFields(t) {
+		if !fieldType.IsExported() || fieldType.Anonymous {
+			continue
+		}
+		if name := strings.Split(fieldType.Tag.Get(jsonTag), ",")[0]; name != "" {
+			jsonNames[name] = true
+		} else if hasRequestTag(fieldType) {
+			goNames = append(goNames, fieldType.Name)
+		}
+	}
+	for key := range data {
+		for _, goName := range goNames {
+			if strings.EqualFold(key, goName) && !jsonNames[key] {
+				delete(data, key)
+			}
+		}
+	}
+}
+
+// hasRequestTag tells if the field is read from the query string, the form
+// or the headers.
+func hasRequestTag(fieldType reflect.StructField) bool {
+	for _, tag := range []string{queryTag, formTag, headerTag} {
+		if key := strings.Split(fieldType.Tag.Get(tag), ",")[0]; key != "" && key != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// requestValues returns the values of the first of the field's `query`,
+// `form` and `header` tags that is present in the request.
+func requestValues(r *http.Request, fieldType reflect.StructField) []string {
+	for _, tag := range []string{queryTag, formTag, headerTag} {
+		key := strings.Split(fieldType.Tag.Get(tag), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		var values []string
+		switch tag {
+		case queryTag:
+			values = r.URL.Query()[key]
+		case formTag:
+			values = r.PostForm[key]
+		case headerTag:
+			values = r.Header.Values(key)
+		}
+		if len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+// coerce converts the received strings to t. A slice takes every repeated
+// value or, when a single one is received, its comma separated items
+// ("?tags=a,b").
+func coerce(values []string, t reflect.Type) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		value, err := coerce(values, t.Elem())
+		if err != nil {
+			return value, err
+		}
+		pointer := reflect.New(t.Elem())
+		pointer.Elem().Set(value)
+		return pointer, nil
+	case reflect.Slice:
+		items := values
+		if len(values) == 1 {
+			items = strings.Split(values[0], ",")
+		}
+		slice := reflect.MakeSlice(t, 0, len(items))
+		for _, item := range items {
+			element, err := validator.CoerceString(strings.TrimSpace(item), t.Elem(), "")
+			if err != nil {
+				return slice, err
+			}
+			slice = reflect.Append(slice, element)
+		}
+		return slice, nil
+	}
+	return validator.CoerceString(values[0], t, "")
+}
@@ -0,0 +1,67 @@
+package httpbind
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+func TestBindFormBodies(t *testing.T) {
+	t.Run("urlencoded", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "/", strings.NewReader("plan=pro"))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		_, err := Bind[signupDTO](request)
+		if got, want := err.RuleTypes(), []string{rules.REQUIRED}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+	t.Run("multipart", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		writer.WriteField("plan", "gold")
+		writer.Close()
+		request := httptest.NewRequest("POST", "/", &body)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+		_, err := Bind[signupDTO](request)
+		if got, want := err.RuleTypes(), []string{rules.REQUIRED, rules.ONE_OF}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+type termDTO struct {
+	Term  string `query:"q" validate:"minlen=2"`
+	Other string `json:"term"`
+}
+
+func TestBindIgnoresBodyKeysOfRequestFields(t *testing.T) {
+	tests := []struct {
+		name, body, term, other string
+	}{
+		{"go name", `{"Term":"a"}`, "", ""},
+		{"other case", `{"TERM":"a"}`, "", ""},
+		{"json name of another field", `{"term":"a"}`, "", "a"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dto, err := Bind[termDTO](httptest.NewRequest("POST", "/", strings.NewReader(test.body)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dto.Term != test.term || dto.Other != test.other {
+				t.Errorf("got %+v, want Term %q and Other %q", *dto, test.term, test.other)
+			}
+		})
+	}
+	t.Run("query", func(t *testing.T) {
+		_, err := Bind[termDTO](httptest.NewRequest("POST", "/?q=a", strings.NewReader(`{"Term":"abc"}`)))
+		if got, want := err.RuleTypes(), []string{rules.MIN_LENGTH}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type resourceDTO struct {
+	Metadata map[string]string         `json:"metadata" validate:"required,dive,keys,minlen=2,pattern=^[a-z]+$,endkeys,required,maxlen=20"`
+	Contacts map[string]string         `json:"contacts" validate:"dive,email"`
+	Quotas   map[string]map[string]int `json:"quotas" validate:"dive,dive,min=1"`
+	Owners   map[string]profileDTO     `json:"owners"`
+	Weights  map[int]float64           `json:"weights" validate:"dive,keys,min=1,endkeys,max=1"`
+}
+
+func TestMaps(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+		want  []string
+	}{
+		{"valid", "", nil, nil},
+		{"required", "metadata", nil, []string{"metadata:required"}},
+		{"keys", "metadata", map[string]interface{}{"a": "x", "Region": "x"}, []string{"metadata[Region]:pattern", "metadata[a]:minlength"}},
+		{"values", "metadata", map[string]interface{}{"region": "a very long region name"}, []string{"metadata[region]:maxlength"}},
+		{"rules of the values", "contacts", map[string]interface{}{"home": "x"}, []string{"contacts[home]:email"}},
+		{"nested maps", "quotas", map[string]interface{}{"eu": map[string]interface{}{"cpu": 0}}, []string{"quotas[eu][cpu]:min"}},
+		{"structs", "owners", map[string]interface{}{"admin": map[string]interface{}{"firstName": "Ann", "email": "x"}}, []string{"owners[admin].email:email"}},
+		{"numeric keys", "weights", map[string]interface{}{"0": 0.5, "2": 1.5}, []string{"weights[0]:min", "weights[2]:max"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := map[string]interface{}{
+				"metadata": map[string]interface{}{"region": "eu"},
+				"owners":   map[string]interface{}{"admin": map[string]interface{}{"firstName": "Ann", "email": "ann@email.com"}},
+				"weights":  map[string]interface{}{"1": 0.5},
+			}
+			if test.field != "" {
+				data[test.field] = test.value
+			}
+			dto, err := ValidateDTO[resourceDTO](data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if test.want == nil && (dto.Metadata["region"] != "eu" || dto.Owners["admin"].FirstName != "Ann" || dto.Weights[1] != 0.5) {
+				t.Errorf("got %+v, want the maps decoded", dto)
+			}
+		})
+	}
+}
+
+func TestMapErrorPath(t *testing.T) {
+	_, err := ValidateDTO[resourceDTO](map[string]interface{}{
+		"metadata": map[string]interface{}{"example.com": "a very long region name"},
+	})
+	if err == nil || len(err.FieldsErrors()) == 0 {
+		t.Fatal("want an error")
+	}
+	if got := err.FieldsErrors()[0].Path(); got != "/metadata/example.com" {
+		t.Errorf("got %q, want %q", got, "/metadata/example.com")
+	}
+}
@@ -0,0 +1,35 @@
+package validator
+
+// Option changes how a single call to ValidateDTO or ValidateDTOPartially
+// validates the data.
+type Option func(*options)
+
+type options struct {
+	coerce bool
+	strict bool
+}
+
+// WithCoercion converts strings to the Go type of every field (numbers,
+// booleans and time.Time) before validating, as the `coerce` hint does for a
+// single field. A rules.TYPE error is reported only when the conversion fails.
+func WithCoercion() Option {
+	return func(o *options) {
+		o.coerce = true
+	}
+}
+
+// WithStrict reports every key of the data that isn't mapped to a field of the
+// struct, including the keys of nested objects, as a rules.UNKNOWN error.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,28 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+)
+
+// plan is the compiled validation schema of a struct type: its fields with
+// the rules already parsed from the `validate` tags. A plan is immutable once
+// stored, so it is shared by concurrent validations of the same type.
+type plan struct {
+	fields []Field
+}
+
+var plans sync.Map // reflect.Type -> *plan
+
+func planOf(t reflect.Type) *plan {
+	if cached, ok := plans.Load(t); ok {
+		return cached.(*plan)
+	}
+	compiled := &plan{fields: buildValidators(reflect.Zero(t).Interface())}
+	cached, _ := plans.LoadOrStore(t, compiled)
+	return cached.(*plan)
+}
+
+func planFor[T interface{}]() *plan {
+	return planOf(reflect.TypeOf((*T)(nil)).Elem())
+}
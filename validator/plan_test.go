@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type planProfile struct {
+	Email string `json:"email" validate:"required,email"`
+	Phone string `json:"phone" validate:"pattern=^\\+?\\d{10\\,13}$"`
+}
+
+type planAddress struct {
+	Street string `json:"street" validate:"required,maxlen=60"`
+	Number int    `json:"number" validate:"min=1"`
+}
+
+type planAccount struct {
+	Name     string        `json:"name" validate:"required,minlen=3,maxlen=40"`
+	Age      int           `json:"age" validate:"required,between=18:120"`
+	Role     string        `json:"role" validate:"oneof=admin|user"`
+	Password string        `json:"password" validate:"required,minlen=8"`
+	Confirm  string        `json:"confirm" validate:"required,eqfield=password"`
+	Address  planAddress   `json:"address"`
+	Profiles []planProfile `json:"profiles" validate:"slice:maxlen=5"`
+}
+
+var planData = map[string]interface{}{
+	"name":     "Test Man",
+	"age":      30,
+	"role":     "admin",
+	"password": "12345678",
+	"confirm":  "12345678",
+	"address":  map[string]interface{}{"street": "Main Street", "number": 10},
+	"profiles": []interface{}{
+		map[string]interface{}{"email": "a@b.co", "phone": "+5511999999999"},
+		map[string]interface{}{"email": "c@d.co"},
+	},
+}
+
+func TestPlanOfIsCached(t *testing.T) {
+	first := planFor[planAccount]()
+	if second := planFor[planAccount](); first != second {
+		t.Error("got a new plan for a type already compiled")
+	}
+	if planOf(reflect.TypeOf(planAccount{})) != first {
+		t.Error("got a different plan from planOf and planFor")
+	}
+	if planFor[planAddress]() == first {
+		t.Error("got the same plan for different types")
+	}
+}
+
+func TestValidateDTOConcurrently(t *testing.T) {
+	invalid := map[string]interface{}{"name": "Te", "age": 10}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				if _, err := ValidateDTO[planAccount](planData); err != nil {
+					t.Errorf("unexpected errors: %v", errorsOf(err))
+				}
+			} else if _, err := ValidateDTO[planAccount](invalid); err == nil {
+				t.Error("invalid data passed")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// BenchmarkValidateDTO validates with the cached plan, which is compiled only
+// once; compare with BenchmarkValidateDTOWithoutPlan to see what it saves.
+func BenchmarkValidateDTO(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ValidateDTO[planAccount](planData); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidateDTOWithoutPlan compiles the fields and rules of the struct
+// on every call, as it was done before plans were cached.
+func BenchmarkValidateDTOWithoutPlan(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, _ := formatJSONData(planData)
+		if errs := tryValidators(data, data, buildValidators(planAccount{}), "", options{}); len(errs) > 0 {
+			b.Fatal(errs)
+		}
+		if _, errs := buildGenericInstance[planAccount](data); len(errs) > 0 {
+			b.Fatal(errs)
+		}
+	}
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	account := planAccount{
+		Name: "Test Man", Age: 30, Role: "admin", Password: "12345678", Confirm: "12345678",
+		Address:  planAddress{"Main Street", 10},
+		Profiles: []planProfile{{"a@b.co", "+5511999999999"}},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := ValidateStruct(&account); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+func init() {
+	rules.RegisterWithContext("sent", func(argument string) func(value interface{}, ctx rules.Context) bool {
+		return func(value interface{}, ctx rules.Context) bool {
+			return ctx.Presence() != rules.Absent
+		}
+	}, nil)
+	rules.RegisterWithContext("notzero", func(argument string) func(value interface{}, ctx rules.Context) bool {
+		return func(value interface{}, ctx rules.Context) bool {
+			return ctx.Presence() != rules.Present || !reflect.ValueOf(value).IsZero()
+		}
+	}, nil)
+}
+
+type presenceDTO struct {
+	Note  *string `json:"note" validate:"sent"`
+	Count *int    `json:"count" validate:"notzero"`
+	Email *string `json:"email" validate:"email" nullable:"false"`
+	Phone *string `json:"phone" validate:"required" nullable:"true"`
+}
+
+func TestValidateDTOPresence(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want []string
+	}{
+		{"absent", map[string]interface{}{}, []string{"note:sent", "phone:required"}},
+		{"null", map[string]interface{}{"note": nil, "count": nil, "phone": nil}, nil},
+		{"zero", map[string]interface{}{"note": "", "count": 0, "phone": ""}, []string{"count:notzero", "phone:required"}},
+		{"present", map[string]interface{}{"note": "a", "count": 1, "email": "a@b.co", "phone": "1"}, nil},
+		{"null not accepted", map[string]interface{}{"note": "a", "email": nil, "phone": "1"}, []string{"email:notnull"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateDTO[presenceDTO](test.data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateStructPresence(t *testing.T) {
+	// a nil pointer is sent as null, so it is never absent
+	zero := 0
+	if got, want := errorsOf(ValidateStruct(presenceDTO{Count: &zero})), []string{"count:notzero", "email:notnull"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
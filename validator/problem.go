@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	ProblemType        = "about:blank"
+)
+
+// Problem is an RFC 7807 "problem details" document describing the errors of
+// a validation.
+type Problem struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemError `json:"errors"`
+}
+
+// ProblemError is a field error inside a Problem, located by a JSON pointer.
+type ProblemError struct {
+	Pointer  string `json:"pointer"`
+	Field    string `json:"field"`
+	RuleType string `json:"rule"`
+	Argument string `json:"argument,omitempty"`
+	Message  string `json:"message"`
+}
+
+func newProblem(fieldErrors []rules.FieldError, locale string) Problem {
+	problem := Problem{
+		Type:   ProblemType,
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Status: http.StatusUnprocessableEntity,
+		Errors: make([]ProblemError, 0, len(fieldErrors)),
+	}
+	for _, fieldError := range fieldErrors {
+		problem.Errors = append(problem.Errors, ProblemError{
+			Pointer:  fieldError.Path(),
+			Field:    fieldError.Name(),
+			RuleType: fieldError.RuleType(),
+			Argument: fieldError.Argument(),
+			Message:  fieldError.MessageIn(locale),
+		})
+	}
+	return problem
+}
+
+// Write sends the problem as an application/problem+json response using its
+// Status as the HTTP status code.
+func (p Problem) Write(w http.ResponseWriter) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+func TestProblem(t *testing.T) {
+	err := NewValidationError(
+		rules.NewErrorByField(rules.MAX_LENGTH, "name", "10"),
+		rules.NewErrorByField(rules.EMAIL_VALIDATION, "profiles[2].email", ""),
+	)
+	got, _ := json.Marshal(err.ProblemIn("pt-BR"))
+	want := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"errors":[` +
+		`{"pointer":"/name","field":"name","rule":"maxlength","argument":"10","message":"o campo 'name' deve ter no máximo 10 caracteres"},` +
+		`{"pointer":"/profiles/2/email","field":"profiles[2].email","rule":"email","message":"o valor informado no campo 'profiles[2].email' não é um email válido"}]}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if err.Problem().Errors[0].Message != "'name' field must have 10 characters at max" {
+		t.Errorf("Problem() isn't in the %s locale: %+v", rules.DefaultLocale, err.Problem())
+	}
+}
+
+func TestProblemWrite(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	problem := NewValidationError(rules.NewErrorByField(rules.REQUIRED, "name", "string")).Problem()
+	if err := problem.Write(recorder); err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Code != http.StatusUnprocessableEntity || recorder.Header().Get("Content-Type") != ProblemContentType {
+		t.Errorf("got %d with %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	var body Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || len(body.Errors) != 1 || body.Errors[0].Pointer != "/name" {
+		t.Errorf("got %s (%v)", recorder.Body, err)
+	}
+}
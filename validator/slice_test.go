@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type profileDTO struct {
+	FirstName string `json:"firstName" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+}
+
+type itemDTO struct {
+	SKU string `json:"sku"`
+}
+
+type collectionsDTO struct {
+	Names    []string     `json:"names" validate:"slice:minlen=1,slice:maxlen=3"`
+	Emails   []string     `json:"emails" validate:"email"`
+	Tags     []string     `json:"tags" validate:"slice:unique,dive,required,maxlen=5"`
+	Matrix   [][]int      `json:"matrix" validate:"slice:maxlen=3,dive,slice:len=2,dive,min=1"`
+	Profiles []profileDTO `json:"profiles" validate:"dive,required"`
+	Items    []itemDTO    `json:"items" validate:"slice:unique=sku"`
+}
+
+func validCollections() map[string]interface{} {
+	return map[string]interface{}{
+		"names":    []interface{}{"a"},
+		"emails":   []interface{}{"a@b.co"},
+		"tags":     []interface{}{"go", "json"},
+		"matrix":   []interface{}{[]interface{}{1, 2}},
+		"profiles": []interface{}{map[string]interface{}{"firstName": "John", "email": "john@email.com"}},
+		"items":    []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "b"}},
+	}
+}
+
+func TestSlices(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+		want  []string
+	}{
+		{"valid", "", nil, nil},
+		{"too few", "names", []interface{}{}, []string{"names:slice:minlen"}},
+		{"too many", "names", []interface{}{"a", "b", "c", "d"}, []string{"names:slice:maxlen"}},
+		{"rules of the elements", "emails", []interface{}{"x", "a@b.co", "y"}, []string{"emails[0]:email", "emails[2]:email"}},
+		{"dive", "tags", []interface{}{"go", "", "golang"}, []string{"tags[1]:required", "tags[2]:maxlength"}},
+		{"unique", "tags", []interface{}{"go", "js", "go"}, []string{"tags[2]:slice:unique"}},
+		{"nested dive", "matrix", []interface{}{[]interface{}{1, 0}, []interface{}{1}}, []string{"matrix[0][1]:min", "matrix[1]:slice:len"}},
+		{"structs", "profiles", []interface{}{
+			map[string]interface{}{"firstName": "John", "email": "john@email.com"},
+			map[string]interface{}{"firstName": "Jane", "email": "Test"},
+		}, []string{"profiles[1].email:email"}},
+		{"null struct", "profiles", []interface{}{nil}, []string{"profiles[0]:required"}},
+		{"not an object", "profiles", []interface{}{"x"}, []string{"profiles[0]:type"}},
+		{"unique key", "items", []interface{}{
+			map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "b"},
+			map[string]interface{}{}, map[string]interface{}{"sku": "a"},
+		}, []string{"items[3]:slice:unique"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := validCollections()
+			if test.field != "" {
+				data[test.field] = test.value
+			}
+			_, err := ValidateDTO[collectionsDTO](data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUniqueMessages(t *testing.T) {
+	data := validCollections()
+	data["tags"] = []interface{}{"go", "js", "go"}
+	data["items"] = []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "a"}}
+	_, err := ValidateDTO[collectionsDTO](data)
+	want := []string{"the 'tags[2]' field duplicates 'tags[0]'", "the 'items[1]' field has the same 'sku' as 'items[0]'"}
+	if err == nil || !reflect.DeepEqual(err.Messages(), want) {
+		t.Errorf("got %q, want %q", err.Messages(), want)
+	}
+}
+
+func TestDiveOnNonContainerPanics(t *testing.T) {
+	type scalarDTO struct {
+		S string `json:"s" validate:"dive,maxlen=1"`
+	}
+	type nestedDTO struct {
+		Tags []string `json:"tags" validate:"dive,dive,maxlen=1"`
+	}
+	for _, validate := range []func(){
+		func() { ValidateDTO[scalarDTO](map[string]interface{}{"s": "abc"}) },
+		func() { ValidateDTO[nestedDTO](map[string]interface{}{"tags": []interface{}{"abc"}}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("a dive on a field that isn't a slice, array or map didn't panic")
+				}
+			}()
+			validate()
+		}()
+	}
+}
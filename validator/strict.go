@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"sort"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+// unknownFields reports the keys of data that aren't the name of any field.
+// Nested structs are flattened in fields ("address.number"), so the keys of
+// their objects are checked here too; the elements of slices of structs, and
+// the structs of recursive fields, are checked by their own call of
+// tryValidators.
+func unknownFields(data map[string]interface{}, fields []Field, prefix string) []rules.FieldError {
+	known := make(map[string]Field, len(fields))
+	for _, field := range fields {
+		known[field.Name()] = field
+	}
+	return unknownKeys(data, known, "", prefix)
+}
+
+func unknownKeys(data map[string]interface{}, known map[string]Field, path, prefix string) []rules.FieldError {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var errs []rules.FieldError
+	for _, key := range keys {
+		name := key
+		if path != "" {
+			name = path + fieldDelimiter + key
+		}
+		field, ok := known[name]
+		if !ok {
+			errs = append(errs, rules.WithValue(rules.NewErrorByField(rules.UNKNOWN, prefix+name, ""), data[key]))
+			continue
+		}
+		if field.Name() == "" {
+			continue // an element of a `dive`, whose fields tryStructElement checks
+		}
+		if nested, ok := data[key].(map[string]interface{}); ok && field.IsStruct() && !field.IsRecursive() && !field.HideParentName() {
+			errs = append(errs, unknownKeys(nested, known, name, prefix)...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type strictProfile struct {
+	Email string `json:"email" validate:"email"`
+}
+
+type strictAddress struct {
+	Street string `json:"street"`
+}
+
+type strictDTO struct {
+	Name        string                   `json:"name"`
+	Address     strictAddress            `json:"address"`
+	HomeAddress strictAddress            `json:"homeAddress"`
+	Profiles    []strictProfile          `json:"profiles" validate:"dive,required"`
+	Owners      map[string]strictProfile `json:"owners" validate:"dive,required"`
+	Groups      [][]strictProfile        `json:"groups" validate:"dive,dive,required"`
+}
+
+func TestValidateDTOStrict(t *testing.T) {
+	profile := map[string]interface{}{"email": "a@b.co"}
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want []string
+	}{
+		{"known keys", map[string]interface{}{"name": "a", "address": map[string]interface{}{"street": "b"}}, nil},
+		{"unknown key", map[string]interface{}{"name": "a", "age": 1}, []string{"age:unknown"}},
+		{"unknown nested key", map[string]interface{}{"address": map[string]interface{}{"number": 1}}, []string{"address.number:unknown"}},
+		{"camelCase nested key", map[string]interface{}{"homeAddress": map[string]interface{}{"street": "b"}}, nil},
+		{"unknown camelCase nested key", map[string]interface{}{"homeAddress": map[string]interface{}{"number": 1}}, []string{"homeAddress.number:unknown"}},
+		{"dive over slice", map[string]interface{}{"profiles": []interface{}{profile}}, nil},
+		{"dive over map", map[string]interface{}{"owners": map[string]interface{}{"k": profile}}, nil},
+		{"dive over nested slices", map[string]interface{}{"groups": []interface{}{[]interface{}{profile}}}, nil},
+		{
+			"unknown key in dive element",
+			map[string]interface{}{"profiles": []interface{}{map[string]interface{}{"email": "a@b.co", "x": 1}}},
+			[]string{"profiles[0].x:unknown"},
+		},
+		{
+			"unknown key in map value",
+			map[string]interface{}{"owners": map[string]interface{}{"k": map[string]interface{}{"x": 1}}},
+			[]string{"owners[k].x:unknown"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateDTO[strictDTO](test.data, WithStrict())
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateDTOWithoutStrictAcceptsUnknownKeys(t *testing.T) {
+	if _, err := ValidateDTO[strictDTO](map[string]interface{}{"age": 1}); err != nil {
+		t.Errorf("got %v, want no errors", errorsOf(err))
+	}
+}
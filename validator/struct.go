@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+// ValidateStruct validates a value that is already a struct (or a pointer to
+// one), reading its fields through reflection instead of a JSON round trip,
+// so values keep their Go types (e.g. int64 doesn't become float64). A nil
+// value or pointer produces a rules.DECODE error, while any other value that
+// isn't a struct is a programming error and panics.
+func ValidateStruct(v interface{}) ValidationError {
+	reflection := reflect.ValueOf(v)
+	for reflection.Kind() == reflect.Ptr && !reflection.IsNil() {
+		reflection = reflection.Elem()
+	}
+	if !reflection.IsValid() {
+		return NewValidationError(rules.NewDecodeError("", "expected a struct, got nil"))
+	} else if reflection.Kind() == reflect.Ptr {
+		return NewValidationError(rules.NewDecodeError("", fmt.Sprintf("expected a struct, got a nil %T", v)))
+	}
+	if reflection.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: ValidateStruct expects a struct or a pointer to a struct, got %T", v))
+	}
+	data, err := structToData(reflection, map[visitedValue]bool{})
+	if err != nil {
+		return NewValidationError(rules.NewDecodeError("", err.Error()))
+	}
+	var fieldsErrors = tryValidators(data, data, planOf(reflection.Type()).fields, "", options{})
+	if len(fieldsErrors) == 0 {
+		return nil
+	}
+	return newValidationError(fieldsErrors)
+}
+
+// visitedValue is a pointer, map or slice being read by valueToData. Like
+// encoding/json, a value found again while it is still being read is a cycle,
+// which would never end.
+type visitedValue struct {
+	pointer uintptr
+	t       reflect.Type
+	length  int
+}
+
+// structToData mirrors the shape formatJSONData produces for a struct, keyed
+// by the same field names, but keeping the original Go values. Values that
+// refer back to themselves produce an error, as in encoding/json.
+func structToData(reflection reflect.Value, visiting map[visitedValue]bool) (map[string]interface{}, error) {
+	data := make(map[string]interface{}, reflection.NumField())
+	for _, fieldType := range jsonFields(reflection.Type()) {
+		fieldValue, err := reflection.FieldByIndexErr(fieldType.Index)
+		if err != nil {
+			continue // err is a nil embedded pointer, whose fields aren't sent
+		}
+		if strings.Contains(fieldType.Tag.Get(jsonTag), omitemptyRule) && isEmptyValue(fieldValue) {
+			continue // same as encoding/json, the key wouldn't be in the payload
+		}
+		value, err := valueToData(fieldValue, visiting)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			data[fieldType.name] = value
+		} else if fieldType.Type.Kind() == reflect.Ptr {
+			data[fieldType.name] = nil // a nil pointer is sent as null, so it is present
+		}
+	}
+	return data, nil
+}
+
+func valueToData(value reflect.Value, visiting map[visitedValue]bool) (interface{}, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if date, ok := value.Interface().(time.Time); ok {
+		if date.IsZero() {
+			return nil, nil // an unset date is missing, as for `required`
+		}
+		return date, nil // kept parsed, so the date rules don't depend on its format
+	}
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return nil, nil
+		}
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return nil, nil
+		}
+		return string(text), nil
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+		if value.Kind() != reflect.Interface {
+			visited := visitedValue{pointer: value.Pointer(), t: value.Type()}
+			if value.Kind() == reflect.Slice {
+				visited.length = value.Len()
+			}
+			if visiting[visited] {
+				return nil, fmt.Errorf("encountered a cycle via %s", value.Type())
+			}
+			visiting[visited] = true
+			defer delete(visiting, visited)
+		}
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return valueToData(value.Elem(), visiting)
+	case reflect.Struct:
+		return structToData(value, visiting)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(value.Bytes()), nil // as encoding/json writes it
+		}
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			item, err := valueToData(value.Index(i), visiting)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case reflect.Map:
+		items := make(map[string]interface{}, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			item, err := valueToData(iterator.Value(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			items[fmt.Sprint(iterator.Key().Interface())] = item
+		}
+		return items, nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return nil, nil
+	}
+	return value.Interface(), nil
+}
+
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Struct:
+		return false
+	}
+	return value.IsZero()
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallrony/go-validator/rules"
+)
+
+type structProfile struct {
+	Email string `json:"email" validate:"email"`
+}
+
+type structDTO struct {
+	Name     string          `json:"name" validate:"required,maxlen=10"`
+	Id       int64           `json:"id" validate:"max=9007199254740993"`
+	Nick     string          `json:"nick,omitempty" validate:"minlen=3"`
+	Born     time.Time       `json:"born" validate:"past"`
+	Profiles []structProfile `json:"profiles"`
+	secret   string
+}
+
+func TestValidateStruct(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{"valid", structDTO{Name: "a", Id: 9007199254740993}, nil},
+		{"pointer", &structDTO{Name: "a"}, nil},
+		{"pointer to pointer", func() interface{} { dto := &structDTO{Name: "a"}; return &dto }(), nil},
+		{"invalid", structDTO{Name: "My Awesome Name"}, []string{"name:maxlength"}},
+		{"int64 precision", structDTO{Name: "a", Id: 9007199254740994}, []string{"id:max"}},
+		{"omitempty", structDTO{Name: "a", Nick: ""}, nil},
+		{"date", structDTO{Name: "a", Born: time.Now().Add(time.Hour)}, []string{"born:past"}},
+		{"slice element", structDTO{Name: "a", Profiles: []structProfile{{"a@b.co"}, {"x"}}}, []string{"profiles[1].email:email"}},
+		{"nil", nil, []string{":decode"}},
+		{"nil pointer", (*structDTO)(nil), []string{":decode"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := errorsOf(ValidateStruct(test.value)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateStructPanicsOnNonStructs(t *testing.T) {
+	for _, value := range []interface{}{1, map[string]interface{}{}, []structDTO{}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ValidateStruct(%#v) didn't panic", value)
+				}
+			}()
+			ValidateStruct(value)
+		}()
+	}
+}
+
+func TestValidateStructCycles(t *testing.T) {
+	loop := &listNode{Name: "a"}
+	loop.Next = loop
+	pair := &listNode{Name: "a", Next: &listNode{Name: "b"}}
+	pair.Next.Next = pair
+	shared := &treeNode{Name: "shared"}
+	tree := &treeNode{Name: "root", Children: []treeNode{{Name: "a", Parent: shared}, {Name: "b", Parent: shared}}}
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{"pointer to itself", loop, []string{":decode"}},
+		{"pointers to each other", pair, []string{":decode"}},
+		{"shared pointer", tree, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := errorsOf(ValidateStruct(test.value)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+	if _, err := ValidateDTO[listNode](loop); err == nil || err.FieldsErrors()[0].RuleType() != rules.DECODE {
+		t.Errorf("ValidateDTO got %v, want a decode error like ValidateStruct", err)
+	}
+}
+
+type bytesDTO struct {
+	Avatar []byte  `json:"avatar" validate:"required"`
+	Hash   [2]byte `json:"hash"`
+}
+
+func TestValidateStructBytes(t *testing.T) {
+	data, _ := structToData(reflect.ValueOf(bytesDTO{Avatar: []byte("abc"), Hash: [2]byte{1, 2}}), map[visitedValue]bool{})
+	// as in encoding/json, byte slices are base64 strings and byte arrays are arrays
+	if want := map[string]interface{}{"avatar": "YWJj", "hash": []interface{}{uint64(1), uint64(2)}}; !reflect.DeepEqual(data, want) {
+		t.Errorf("got %#v, want %#v", data, want)
+	}
+	for _, value := range []bytesDTO{{Avatar: []byte("abc")}, {Avatar: []byte{}}, {}} {
+		_, err := ValidateDTO[bytesDTO](value)
+		if got, want := errorsOf(ValidateStruct(value)), errorsOf(err); !reflect.DeepEqual(got, want) {
+			t.Errorf("ValidateStruct(%v) got %v, want %v as ValidateDTO", value, got, want)
+		}
+	}
+}
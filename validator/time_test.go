@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type personDTO struct {
+	BirthDate time.Time  `json:"birth_date" validate:"required,date=02/01/2006,past,minage=18,maxage=120"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"datetime,future"`
+	StartedAt time.Time  `json:"started_at" validate:"after=2020-01-01,before=2030-01-01T00:00:00Z"`
+	EndedAt   time.Time  `json:"ended_at" validate:"gtfield=started_at"`
+}
+
+func TestTimeFields(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+		want  []string
+	}{
+		{"valid", "", nil, nil},
+		{"missing", "birth_date", nil, []string{"birth_date:required"}},
+		{"wrong format", "birth_date", "2000-01-01", []string{"birth_date:date"}},
+		{"future birth date", "birth_date", now.AddDate(1, 0, 0).Format("02/01/2006"), []string{"birth_date:past"}},
+		{"too young", "birth_date", now.AddDate(-10, 0, 0).Format("02/01/2006"), []string{"birth_date:minage"}},
+		{"too old", "birth_date", now.AddDate(-150, 0, 0).Format("02/01/2006"), []string{"birth_date:maxage"}},
+		{"expired", "expires_at", now.Add(-time.Hour).Format(time.RFC3339), []string{"expires_at:future"}},
+		{"not a datetime", "expires_at", "tomorrow", []string{"expires_at:date"}},
+		{"before the range", "started_at", "2019-06-01T00:00:00Z", []string{"started_at:after"}},
+		{"after the range", "started_at", "2031-06-01T00:00:00Z", []string{"started_at:before", "ended_at:gtfield"}},
+		{"before the other field", "ended_at", "2021-01-01T00:00:00Z", []string{"ended_at:gtfield"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := map[string]interface{}{
+				"birth_date": "15/06/1990",
+				"expires_at": now.Add(time.Hour).Format(time.RFC3339),
+				"started_at": "2024-01-01T00:00:00Z",
+				"ended_at":   "2025-01-01T00:00:00Z",
+			}
+			if test.field != "" {
+				data[test.field] = test.value
+			}
+			dto, err := ValidateDTO[personDTO](data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if test.want == nil && !dto.BirthDate.Equal(time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("got %v, want the birth date parsed in its format", dto.BirthDate)
+			}
+		})
+	}
+}
+
+func TestValidateStructZeroTimeIsMissing(t *testing.T) {
+	err := ValidateStruct(&personDTO{})
+	if got, want := errorsOf(err), []string{"birth_date:required"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
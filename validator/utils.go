@@ -1,10 +1,204 @@
 package validator
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 
-func formatJSONData(data interface{}) map[string]interface{} {
+	"github.com/wallrony/go-validator/rules"
+)
+
+// formatJSONData converts the data to the shape it would have after a JSON
+// round trip. Data that can't be written as a JSON object (channels, NaN, a
+// slice...) produces a rules.DECODE error.
+func formatJSONData(data interface{}) (map[string]interface{}, rules.FieldError) {
 	var formattedData map[string]interface{}
-	dataBytes, _ := json.Marshal(data)
-	json.Unmarshal(dataBytes, &formattedData)
-	return formattedData
+	dataBytes, err := json.Marshal(data)
+	if err == nil {
+		err = json.Unmarshal(dataBytes, &formattedData)
+	}
+	if err != nil {
+		return nil, rules.NewDecodeError("", err.Error())
+	}
+	return formattedData, nil
+}
+
+// decodeErrorOf describes an error of json.Unmarshal into a t. A value of the
+// wrong type for its struct field produces a rules.TYPE error with the path of
+// the field and its Go type; anything else, a rules.DECODE error. remove
+// deletes the value from data, so the others can still be decoded, and is nil
+// when there is nothing to remove.
+func decodeErrorOf(err error, t reflect.Type, data map[string]interface{}) (fieldError rules.FieldError, remove func()) {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		name, value, remove := jsonPathOf(t, data, typeError.Field)
+		fieldError := rules.NewErrorByField(rules.TYPE, name, typeError.Type.String())
+		return rules.WithValue(fieldError, value), remove
+	}
+	return rules.NewDecodeError("", err.Error()), nil
+}
+
+// jsonPathOf follows a path of encoding/json, such as "profiles.2.email" or
+// "hosts.example.com", through t and data to name it the way fields are named
+// ("profiles[2].email", "hosts[example.com]"). Keys are matched against data,
+// since they may have dots. When the path isn't found, it is named as is and
+// remove is nil.
+func jsonPathOf(t reflect.Type, data map[string]interface{}, path string) (name string, value interface{}, remove func()) {
+	segments := strings.Split(path, fieldDelimiter)
+	value = data
+	for len(segments) > 0 {
+		if t != nil {
+			t = derefType(t)
+		}
+		switch container := value.(type) {
+		case map[string]interface{}:
+			n := len(segments)
+			for ; n > 0; n-- {
+				if _, found := container[strings.Join(segments[:n], fieldDelimiter)]; found {
+					break
+				}
+			}
+			if n == 0 {
+				return path, nil, nil
+			}
+			key := strings.Join(segments[:n], fieldDelimiter)
+			segments = segments[n:]
+			if t != nil && t.Kind() == reflect.Map {
+				name, t = fmt.Sprintf("%s[%s]", name, key), t.Elem()
+			} else {
+				name, t = joinPath(name, key), fieldTypeOf(t, key)
+			}
+			value, remove = container[key], func() { delete(container, key) }
+		case []interface{}:
+			index, err := strconv.Atoi(segments[0])
+			if err != nil || index < 0 || index >= len(container) {
+				return path, nil, nil
+			}
+			segments = segments[1:]
+			name = fmt.Sprintf("%s[%d]", name, index)
+			if t != nil {
+				t = t.Elem()
+			}
+			value, remove = container[index], func() { container[index] = nil }
+		default:
+			return path, nil, nil
+		}
+	}
+	return name, value, remove
+}
+
+// copyData copies the maps and slices of the formatted data, so they can be
+// changed without changing the original.
+func copyData(data interface{}) interface{} {
+	switch container := data.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(container))
+		for key, value := range container {
+			copied[key] = copyData(value)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(container))
+		for i, value := range container {
+			copied[i] = copyData(value)
+		}
+		return copied
+	}
+	return data
+}
+
+// fieldTypeOf is the type of the field of the struct t named name, or nil.
+func fieldTypeOf(t reflect.Type, name string) reflect.Type {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, field := range jsonFields(t) {
+		if field.name == name {
+			return field.Type
+		}
+	}
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + fieldDelimiter + key
+}
+
+// extractPath walks the formatted data following a field path such as
+// "profiles[2].email", returning nil when any step of the path is missing.
+func extractPath(data interface{}, path string) interface{} {
+	value, _ := lookupPath(data, path)
+	return value
+}
+
+// lookupPath is extractPath telling, in addition, whether the last key of the
+// path exists, so a null value can be told apart from a missing one.
+func lookupPath(data interface{}, path string) (interface{}, bool) {
+	value := data
+	for _, key := range strings.Split(path, fieldDelimiter) {
+		name, indexes := splitIndexes(key)
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = v[name]; !ok {
+			return nil, false
+		}
+		for _, index := range indexes {
+			items, ok := value.([]interface{})
+			if !ok || index >= len(items) {
+				return nil, false
+			}
+			value = items[index]
+		}
+	}
+	return value, true
+}
+
+// presenceOf tells how the value of a field path was received.
+func presenceOf(data map[string]interface{}, path string) rules.Presence {
+	if value, found := lookupPath(data, path); !found {
+		return rules.Absent
+	} else if value == nil {
+		return rules.Null
+	}
+	return rules.Present
+}
+
+// setPath replaces the value at a field path such as "address.number" when
+// every map before it exists.
+func setPath(data map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, fieldDelimiter)
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := data[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		data = next
+	}
+	data[keys[len(keys)-1]] = value
+}
+
+// splitIndexes separates a path segment like "profiles[2]" into its key and
+// the list of slice indexes that follow it.
+func splitIndexes(segment string) (string, []int) {
+	start := strings.Index(segment, "[")
+	if start < 0 {
+		return segment, nil
+	}
+	var indexes []int
+	for _, part := range strings.Split(segment[start+1:], "[") {
+		index, err := strconv.Atoi(strings.TrimSuffix(part, "]"))
+		if err != nil {
+			return segment, nil
+		}
+		indexes = append(indexes, index)
+	}
+	return segment[:start], indexes
 }
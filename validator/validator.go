@@ -1,21 +1,85 @@
 package validator
 
 import (
+	"bytes"
 	"encoding/json"
-	"github.com/wallrony/go-validator/rules"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/wallrony/go-validator/rules"
 )
 
-func ValidateDTOPartially[T interface{}](data interface{}) (*T, ValidationError) {
-	return buildGenericInstance[T](data), validate[T](data)
+func ValidateDTOPartially[T interface{}](data interface{}, opts ...Option) (*T, ValidationError) {
+	formattedData, err := validate[T](data, newOptions(opts))
+	if formattedData == nil {
+		return new(T), err
+	}
+	instance, buildErrs := buildGenericInstance[T](formattedData)
+	return instance, withBuildErrors(err, buildErrs)
 }
 
-func ValidateDTO[T interface{}](data interface{}) (*T, ValidationError) {
-	err := validate[T](data)
-	if err != nil {
+func ValidateDTO[T interface{}](data interface{}, opts ...Option) (*T, ValidationError) {
+	formattedData, err := validate[T](data, newOptions(opts))
+	if formattedData == nil {
 		return nil, err
 	}
-	return buildGenericInstance[T](data), nil
+	instance, buildErrs := buildGenericInstance[T](formattedData)
+	if err = withBuildErrors(err, buildErrs); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// withBuildErrors adds the values that didn't fit their fields to the errors
+// of the validation, except for the fields (and their elements) that already
+// failed it, since the two would usually tell the same.
+func withBuildErrors(err ValidationError, buildErrs []rules.FieldError) ValidationError {
+	if err == nil && len(buildErrs) == 0 {
+		return nil
+	}
+	var fieldsErrors []rules.FieldError
+	if err != nil {
+		fieldsErrors = err.FieldsErrors()
+	}
+	var failed []string // prefixes of the fields that failed and of their elements
+	for _, fieldError := range fieldsErrors {
+		failed = append(failed, fieldError.Name()+fieldDelimiter, fieldError.Name()+"[")
+	}
+	for _, buildErr := range buildErrs {
+		if !hasAnyPrefix(buildErr.Name()+fieldDelimiter, failed) {
+			fieldsErrors = append(fieldsErrors, buildErr)
+		}
+	}
+	return newValidationError(fieldsErrors)
+}
+
+// ValidateJSON decodes a JSON object and validates it as a T.
+func ValidateJSON[T interface{}](data []byte, opts ...Option) (*T, ValidationError) {
+	return ValidateReader[T](bytes.NewReader(data), opts...)
+}
+
+// ValidateReader reads a JSON object and validates it as a T. Input that
+// isn't a single JSON object produces a rules.DECODE error.
+func ValidateReader[T interface{}](r io.Reader, opts ...Option) (*T, ValidationError) {
+	var data map[string]interface{}
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, NewValidationError(rules.NewDecodeError("", err.Error()))
+	}
+	if data == nil {
+		return nil, NewValidationError(rules.NewDecodeError("", "expected a JSON object, got null"))
+	}
+	if decoder.More() {
+		return nil, NewValidationError(rules.NewDecodeError("", "unexpected data after the JSON object"))
+	}
+	return ValidateDTO[T](data, opts...)
 }
 
 func buildValidators(instance interface{}) []Field {
@@ -23,55 +87,218 @@ func buildValidators(instance interface{}) []Field {
 	if reflection.Kind() == reflect.Ptr {
 		reflection = reflection.Elem()
 	}
+	return buildFields(reflection.Type(), map[reflect.Type]bool{})
+}
+
+// buildFields creates the fields of a struct, flattening its nested structs.
+// visiting holds the structs being flattened, so a field that points back to
+// one of them (as in `Next *Node`) isn't flattened forever: it is marked as
+// recursive and its fields are validated per value instead.
+func buildFields(t reflect.Type, visiting map[reflect.Type]bool) []Field {
+	visiting[t] = true
+	defer delete(visiting, t)
 	var fields []Field
-	for i := 0; i < reflection.NumField(); i++ {
-		fieldValue := reflection.Field(i)
-		fieldType := reflection.Type().Field(i)
-		field := newField(fieldType, fieldValue)
-		fields = append(fields, field)
-		if field.IsStruct() || field.IsSlice() {
-			fields = append(fields, field.GenerateNestedFields()...)
+	for _, jsonField := range jsonFields(t) {
+		f := newField(jsonField.StructField, reflect.Zero(jsonField.Type)).(*field)
+		fields = append(fields, f)
+		if f.IsStruct() && visiting[f.valueType] {
+			f.recursive = true
+		} else if f.IsStruct() {
+			fields = append(fields, f.nestedFields(visiting)...)
 		}
 	}
 	return fields
 }
 
-func tryValidators(data interface{}, fields []Field) []rules.FieldError {
-	var errs []rules.FieldError
-	var formattedData map[string]interface{} = formatJSONData(data)
+func tryValidators(root, data map[string]interface{}, fields []Field, prefix string, opts options) []rules.FieldError {
+	errs, coercionFailed := coerceFields(data, fields, prefix, opts)
+	if opts.strict {
+		errs = append(errs, unknownFields(data, fields, prefix)...)
+	}
+	var skipped []string // prefixes of the nested fields of null structs
+	var rejectedDates []string
 	for _, field := range fields {
+		if coercionFailed[field.Name()] || hasAnyPrefix(field.Name(), skipped) {
+			continue
+		}
+		name := prefix + field.Name()
+		presence := presenceOf(data, field.Name())
+		if nullable, set := field.Nullable(); set && presence == rules.Null {
+			if !nullable {
+				errs = append(errs, rules.NewErrorByField(rules.NOT_NULL, name, ""))
+			}
+			skipped = append(skipped, field.Name()+fieldDelimiter)
+			continue
+		}
 		if field.IsStruct() {
+			if (field.IsPointer() || field.Name() == "") && presence != rules.Present {
+				// a nil pointer (or a null element of a `dive`, which has no
+				// name) has no fields to validate, it is only missing
+				skipped = append(skipped, field.Name()+fieldDelimiter)
+				if fieldErrs, ok := field.IsValid(name, nil, newFieldContext(root, data, field, presence)); !ok && field.IsRequired() {
+					errs = append(errs, fieldErrs...)
+				}
+			} else if field.IsRecursive() {
+				errs = append(errs, tryStructElement(root, field, name, field.ExtractValueFrom(data), opts)...)
+			}
 			continue
 		}
-		value := field.ExtractValueFrom(formattedData)
+		value := field.ExtractValueFrom(data)
 		canJump := field.ValidateIfExists() && value == nil
 		if value != nil && field.TypeName() == reflect.Slice.String() {
 			canJump = field.ValidateIfExists() && reflect.ValueOf(value).Len() == 0
 		}
+		canJump = canJump && !field.IsConditionallyRequired()
 		if canJump {
 			continue
 		}
-		field.SetValue(value)
-		if fieldErrs, ok := field.IsValid(); !ok {
+		if fieldErrs, ok := field.IsValid(name, value, newFieldContext(root, data, field, presence)); !ok {
 			errs = append(errs, fieldErrs...)
+			if isTimeType(field.ReflectType()) {
+				rejectedDates = append(rejectedDates, field.Name())
+			}
+		} else {
+			errs = append(errs, coerceTime(data, field, name, value)...)
+		}
+		if field.IsSlice() {
+			errs = append(errs, tryElementValidators(root, field, name, value, opts)...)
+		} else if field.IsMap() {
+			errs = append(errs, tryMapValidators(root, field, name, value, opts)...)
 		}
 	}
+	// a rejected date can't be parsed when building the instance either, where
+	// encoding/json wouldn't tell which field it was; it's dropped only now, so
+	// the cross-field rules of the other fields still see it
+	for _, name := range rejectedDates {
+		setPath(data, name, nil)
+	}
 	return errs
 }
 
-func validate[T interface{}](data interface{}) ValidationError {
-	var it T
-	var validators []Field = buildValidators(it)
-	var fieldsErrors = tryValidators(data, validators)
-	if len(fieldsErrors) == 0 {
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// tryElementValidators validates the elements of a slice with the hints after
+// `dive` and, in slices of structs, the fields of every element.
+func tryElementValidators(root map[string]interface{}, field Field, name string, value interface{}, opts options) []rules.FieldError {
+	var errs []rules.FieldError
+	elements, _ := value.([]interface{})
+	for i := range elements {
+		elementName := fmt.Sprintf("%s[%d]", name, i)
+		if elementField := field.DiveElementField(); elementField != nil {
+			var elementErrs []rules.FieldError
+			elementErrs, elements[i] = tryDiveElement(root, elementField, elementName, elements[i], opts)
+			errs = append(errs, elementErrs...)
+		}
+		if field.IsStructSlice() {
+			errs = append(errs, tryStructElement(root, field, elementName, elements[i], opts)...)
+		}
+	}
+	return errs
+}
+
+// tryMapValidators validates the keys and values of a map with the hints
+// after `dive` and, in maps of structs, the fields of every value. Elements
+// are named by their keys, as in "metadata[region]".
+func tryMapValidators(root map[string]interface{}, field Field, name string, value interface{}, opts options) []rules.FieldError {
+	var errs []rules.FieldError
+	entries, _ := value.(map[string]interface{})
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		elementName := fmt.Sprintf("%s[%s]", name, key)
+		if keyField := field.DiveKeyField(); keyField != nil {
+			// keys are always strings in JSON, even the keys of a map[int]T
+			if typedKey, ok := coerceValue(key, keyField.ReflectType(), ""); ok {
+				keyErrs, _ := tryDiveElement(root, keyField, elementName, typedKey, opts)
+				errs = append(errs, keyErrs...)
+			} else {
+				errs = append(errs, NewTypeError(elementName, keyField.ReflectType(), key))
+			}
+		}
+		if elementField := field.DiveElementField(); elementField != nil {
+			var elementErrs []rules.FieldError
+			elementErrs, entries[key] = tryDiveElement(root, elementField, elementName, entries[key], opts)
+			errs = append(errs, elementErrs...)
+		}
+		if field.IsStructMap() {
+			errs = append(errs, tryStructElement(root, field, elementName, entries[key], opts)...)
+		}
+	}
+	return errs
+}
+
+// tryDiveElement validates an element with the field made of the hints after
+// `dive`, returning the element as converted by it (e.g. a time.Time). The
+// element is held by an unnamed key, since the field has no name.
+func tryDiveElement(root map[string]interface{}, diveField Field, name string, element interface{}, opts options) ([]rules.FieldError, interface{}) {
+	holder := map[string]interface{}{"": element}
+	errs := tryValidators(root, holder, []Field{diveField}, name, opts)
+	return errs, holder[""]
+}
+
+// tryStructElement validates the fields of an element of a slice or map of
+// structs, or of the struct of a recursive field. A nil pointer element has
+// no fields to validate, and neither has a null element of a field with
+// `dive`, whose rules decide if null is valid.
+func tryStructElement(root map[string]interface{}, field Field, name string, element interface{}, opts options) []rules.FieldError {
+	if element == nil && (field.DiveElementField() != nil || derefType(field.ReflectType()).Elem().Kind() == reflect.Ptr) {
 		return nil
 	}
-	return newValidationError(fieldsErrors)
+	elementData, ok := element.(map[string]interface{})
+	if !ok && element != nil {
+		return []rules.FieldError{rules.WithValue(rules.NewErrorByField(rules.TYPE, name, reflect.Struct.String()), element)}
+	}
+	return tryValidators(root, elementData, field.ElementFields(), name+fieldDelimiter, opts)
 }
 
-func buildGenericInstance[T interface{}](data interface{}) *T {
-	dataStr, _ := json.Marshal(formatJSONData(data))
-	var instance T
-	json.Unmarshal(dataStr, &instance)
-	return &instance
+// validate returns the formatted data, with the coerced values when coercion
+// is enabled, along with the errors.
+func validate[T interface{}](data interface{}, opts options) (map[string]interface{}, ValidationError) {
+	formattedData, decodeErr := formatJSONData(data)
+	if decodeErr != nil {
+		return nil, NewValidationError(decodeErr)
+	}
+	var fieldsErrors = tryValidators(formattedData, formattedData, planFor[T]().fields, "", opts)
+	if len(fieldsErrors) == 0 {
+		return formattedData, nil
+	}
+	return formattedData, newValidationError(fieldsErrors)
+}
+
+// buildGenericInstance fills a T with the formatted data. Every value that
+// doesn't fit its field is reported and left out, so the other fields are
+// still filled. json.Unmarshal only tells the first one, so it is run again
+// without it until the data fits. The values are removed from a copy of the
+// data, since the errors of the validation may hold them.
+func buildGenericInstance[T interface{}](data map[string]interface{}) (*T, []rules.FieldError) {
+	var errs []rules.FieldError
+	for {
+		instance := new(T)
+		dataStr, err := json.Marshal(data)
+		if err == nil {
+			err = json.Unmarshal(dataStr, instance)
+		}
+		if err == nil {
+			return instance, errs
+		}
+		if len(errs) == 0 {
+			data = copyData(data).(map[string]interface{})
+		}
+		fieldError, remove := decodeErrorOf(err, reflect.TypeOf(instance).Elem(), data)
+		errs = append(errs, fieldError)
+		if remove == nil {
+			return instance, errs
+		}
+		remove()
+	}
 }
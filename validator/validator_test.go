@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type listNode struct {
+	Name string    `json:"name" validate:"required"`
+	Next *listNode `json:"next"`
+}
+
+type treeNode struct {
+	Name     string     `json:"name" validate:"required"`
+	Parent   *treeNode  `json:"parent"`
+	Children []treeNode `json:"children"`
+}
+
+func TestValidateDTORecursiveTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want []string
+	}{
+		{"single node", map[string]interface{}{"name": "a"}, nil},
+		{"null next", map[string]interface{}{"name": "a", "next": nil}, nil},
+		{"valid chain", map[string]interface{}{"name": "a", "next": map[string]interface{}{"name": "b"}}, nil},
+		{
+			"invalid second node",
+			map[string]interface{}{"name": "a", "next": map[string]interface{}{}},
+			[]string{"next.name:required"},
+		},
+		{
+			"invalid third node",
+			map[string]interface{}{"name": "a", "next": map[string]interface{}{"name": "b", "next": map[string]interface{}{"name": ""}}},
+			[]string{"next.next.name:required"},
+		},
+		{"next not an object", map[string]interface{}{"name": "a", "next": "b"}, []string{"next:type"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateDTO[listNode](test.data)
+			if got := errorsOf(err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateDTORecursiveTypeThroughSlice(t *testing.T) {
+	data := map[string]interface{}{
+		"name":     "root",
+		"parent":   map[string]interface{}{"name": ""},
+		"children": []interface{}{map[string]interface{}{"name": "a", "parent": map[string]interface{}{}}},
+	}
+	_, err := ValidateDTO[treeNode](data, WithStrict())
+	want := []string{"parent.name:required", "children[0].parent.name:required"}
+	if got := errorsOf(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestValidateStructRecursiveType(t *testing.T) {
+	node := &listNode{Name: "a", Next: &listNode{Next: &listNode{Name: "c"}}}
+	want := []string{"next.name:required"}
+	if got := errorsOf(ValidateStruct(node)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}